@@ -6,18 +6,28 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/omarshaarawi/coachbot/internal/api/espn"
 	"github.com/omarshaarawi/coachbot/internal/api/fantasy"
 	"github.com/omarshaarawi/coachbot/internal/bot"
 	"github.com/omarshaarawi/coachbot/internal/config"
+	"github.com/omarshaarawi/coachbot/internal/health"
+	"github.com/omarshaarawi/coachbot/internal/leader"
+	"github.com/omarshaarawi/coachbot/internal/live"
+	"github.com/omarshaarawi/coachbot/internal/metrics"
 	"github.com/omarshaarawi/coachbot/internal/repository/memory"
 	"github.com/omarshaarawi/coachbot/internal/scheduler"
 	"github.com/omarshaarawi/coachbot/internal/service"
 )
 
+// leaderCheckInterval is how often a standby checks whether it can take over
+// handling Telegram updates.
+const leaderCheckInterval = 5 * time.Second
+
 func main() {
 	if err := run(); err != nil {
 		slog.Error("Error running application", "error", err)
@@ -40,17 +50,93 @@ func run() error {
 	fantasyAPI := fantasy.NewAPI(espnAPI)
 
 	repo := memory.NewRepository()
+	if err := repo.PersistJobs(filepath.Join(cfg.Storage.DataDir, "jobs.json")); err != nil {
+		return err
+	}
+	if err := repo.PersistOutbound(filepath.Join(cfg.Storage.DataDir, "outbound.json")); err != nil {
+		return err
+	}
+	if err := repo.PersistWatchlists(filepath.Join(cfg.Storage.DataDir, "watchlists.json")); err != nil {
+		return err
+	}
+	if err := repo.PersistResults(filepath.Join(cfg.Storage.DataDir, "results.json")); err != nil {
+		return err
+	}
+	if err := repo.PersistAnnouncedScores(filepath.Join(cfg.Storage.DataDir, "announced.json")); err != nil {
+		return err
+	}
+	if err := repo.PersistSeenTransactions(filepath.Join(cfg.Storage.DataDir, "transactions.json")); err != nil {
+		return err
+	}
+	if err := repo.PersistAlertChats(filepath.Join(cfg.Storage.DataDir, "alerts.json")); err != nil {
+		return err
+	}
 	fantasyService := service.NewFantasyService(fantasyAPI, repo)
 
-	telegramBot, err := bot.NewTelegramBot(cfg.TelegramBot.Token, cfg.TelegramBot.ChatID, fantasyService)
+	// Only the instance holding the lock sends scheduled reports and handles
+	// updates, so overlapping containers during a deploy don't double-post.
+	elector, err := leader.NewElector(filepath.Join(cfg.Storage.DataDir, "leader.lock"))
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err := elector.Resign(); err != nil {
+			slog.Error("Error releasing leader lock", "error", err)
+		}
+	}()
 
-	sched, err := scheduler.NewScheduler(fantasyService, telegramBot.SendMessage)
+	telegramBot, err := bot.NewTelegramBot(cfg.TelegramBot, fantasyService)
 	if err != nil {
 		return err
 	}
+	if err := telegramBot.PersistQueue(filepath.Join(cfg.Storage.DataDir, "queue.json")); err != nil {
+		return err
+	}
+
+	sched, err := scheduler.NewScheduler(fantasyService, repo, telegramBot, cfg.TelegramBot.ChatID, cfg.TelegramBot.AdminIDs, cfg.Scheduler, elector)
+	if err != nil {
+		return err
+	}
+	telegramBot.SetJobs(sched)
+
+	var watchers []live.Watcher
+	if cfg.Live.BoardEnabled {
+		watchers = append(watchers, live.NewBoard(telegramBot, []int64{cfg.TelegramBot.ChatID}))
+	}
+	if cfg.Live.AlertsEnabled {
+		// Only the leader writes to the data directory. This runs after the
+		// scheduler has reloaded the subscriptions the last leader saved.
+		elector.OnElected(func() { repo.AddDefaultAlertChat(cfg.TelegramBot.ChatID) })
+		watchers = append(watchers, live.NewDetector(repo, telegramBot, cfg.Live))
+	}
+	if cfg.Live.ResultsEnabled {
+		watchers = append(watchers, live.NewResults(fantasyService, repo, telegramBot, []int64{cfg.TelegramBot.ChatID}, cfg.Live.CatchUpFactor))
+	}
+	if len(watchers) > 0 {
+		poller := live.NewPoller(fantasyService, sched.Location(), watchers...)
+		if err := sched.Every("live scores", cfg.Live.PollInterval, poller.Poll); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Injuries.AlertsEnabled {
+		injuries := live.NewInjuries(fantasyService, telegramBot, cfg.TelegramBot.ChatID, cfg.Injuries.TeamChats)
+		if err := sched.Every("injury statuses", cfg.Injuries.PollInterval, injuries.Poll); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Transactions.Enabled {
+		transactions := live.NewTransactions(fantasyService, telegramBot, cfg.TelegramBot.ChatID)
+		if err := sched.Every("transactions", cfg.Transactions.PollInterval, transactions.Poll); err != nil {
+			return err
+		}
+	}
+
+	watchlist := live.NewWatchlist(fantasyService, telegramBot)
+	if err := sched.Every("watchlist", cfg.Watchlist.PollInterval, watchlist.Poll); err != nil {
+		return err
+	}
 
 	if err := sched.Start(); err != nil {
 		return err
@@ -62,7 +148,20 @@ func run() error {
 		}
 	}()
 
-	http.HandleFunc("/", healthCheckHandler)
+	// A restart fixes a dead update loop or scheduler, but not expired ESPN
+	// cookies or a full disk, so those only make the bot unready.
+	checker := health.NewChecker()
+	checker.Live("telegram", telegramBot.Health)
+	checker.Live("scheduler", sched.Health)
+	checker.Ready("espn", espnClient.Health)
+	checker.Ready("repository", repo.Health)
+
+	http.Handle("/healthz", checker.Healthz())
+	http.Handle("/readyz", checker.Readyz())
+	http.Handle("/metrics", metrics.Handler())
+	if path, handler, ok := telegramBot.WebhookHandler(); ok {
+		http.Handle(path, handler)
+	}
 
 	go func() {
 		if err := http.ListenAndServe(":80", nil); err != nil {
@@ -74,6 +173,9 @@ func run() error {
 	defer stop()
 
 	go func() {
+		if err := elector.Wait(ctx, leaderCheckInterval); err != nil {
+			return
+		}
 		if err := telegramBot.Start(ctx); err != nil {
 			slog.Error("Error running telegram bot", "error", err)
 		}
@@ -84,7 +186,3 @@ func run() error {
 
 	return nil
 }
-
-func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusOK)
-}
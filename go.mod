@@ -7,15 +7,24 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/kelseyhightower/envconfig v1.4.0
 	github.com/lithammer/fuzzysearch v1.1.8
+	github.com/prometheus/client_golang v1.24.1
 )
 
 require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/jonboulle/clockwork v0.5.0 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_model v0.6.2 // indirect
+	github.com/prometheus/common v0.70.1 // indirect
+	github.com/prometheus/procfs v0.21.1 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
 )
 
 require (
 	github.com/go-co-op/gocron/v2 v2.16.5
-	github.com/robfig/cron/v3 v3.0.1 // indirect
-	golang.org/x/text v0.28.0 // indirect
+	github.com/robfig/cron/v3 v3.0.1
+	golang.org/x/text v0.40.0 // indirect
 )
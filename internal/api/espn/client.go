@@ -1,20 +1,35 @@
 package espn
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/omarshaarawi/coachbot/internal/config"
+	"github.com/omarshaarawi/coachbot/internal/health"
+	"github.com/omarshaarawi/coachbot/internal/metrics"
 )
 
 const baseURL = "https://lm-api-reads.fantasy.espn.com/apis/v3/games/ffl"
 
+// staleAfter is how long ESPN can fail before the client reports itself as
+// failing.
+const staleAfter = time.Hour
+
 type Client struct {
 	httpClient *http.Client
 	Config     config.ESPNAPI
+
+	mu           sync.Mutex
+	lastSuccess  time.Time
+	lastFailure  time.Time
+	lastErr      error
+	authRejected bool
 }
 
 func NewClient(cfg config.ESPNAPI) *Client {
@@ -47,21 +62,70 @@ func (c *Client) Get(endpoint string, params, headers map[string]string, result
 		req.Header.Set(key, value)
 	}
 
+	view := params["view"]
+	if view == "" {
+		view = "none"
+	}
+
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
+	metrics.ESPNRequestDuration.WithLabelValues(view).Observe(time.Since(start).Seconds())
 	if err != nil {
-		return fmt.Errorf("error making request: %w", err)
+		metrics.ESPNRequests.WithLabelValues(view, "error").Inc()
+		return c.record(0, fmt.Errorf("error making request: %w", err))
 	}
 	defer resp.Body.Close()
 
+	metrics.ESPNRequests.WithLabelValues(view, strconv.Itoa(resp.StatusCode)).Inc()
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return c.record(resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode))
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-		return fmt.Errorf("error decoding response: %w", err)
+		return c.record(resp.StatusCode, fmt.Errorf("error decoding response: %w", err))
 	}
 
-	return nil
+	metrics.ESPNLastSuccess.WithLabelValues(view).SetToCurrentTime()
+	return c.record(resp.StatusCode, nil)
+}
+
+// record keeps the outcome of a request for Health and returns err.
+func (c *Client) record(status int, err error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		c.lastSuccess = time.Now()
+		c.authRejected = false
+		return nil
+	}
+
+	c.lastFailure = time.Now()
+	c.lastErr = err
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		c.authRejected = true
+	}
+	return err
+}
+
+// Health reports whether ESPN accepts the league cookies and has answered
+// recently.
+func (c *Client) Health(context.Context) health.Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case c.authRejected:
+		return health.Failing(fmt.Sprintf("ESPN rejected the SWID and ESPN_S2 cookies at %s: %v", c.lastFailure.Format(time.RFC3339), c.lastErr))
+	case c.lastSuccess.IsZero() && c.lastFailure.IsZero():
+		return health.OK("no requests yet")
+	case c.lastFailure.After(c.lastSuccess) && time.Since(c.lastSuccess) > staleAfter:
+		if c.lastSuccess.IsZero() {
+			return health.Failing(fmt.Sprintf("no successful request yet: %v", c.lastErr))
+		}
+		return health.Failing(fmt.Sprintf("no successful request since %s: %v", c.lastSuccess.Format(time.RFC3339), c.lastErr))
+	}
+	return health.OK("last successful request at " + c.lastSuccess.Format(time.RFC3339))
 }
 
 func (c *Client) setCookies(req *http.Request) {
@@ -0,0 +1,125 @@
+package espn
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+var positionSlotIDs = map[string]int{
+	"QB":   0,
+	"RB":   2,
+	"WR":   4,
+	"TE":   6,
+	"FLEX": 23,
+	"D/ST": 16,
+	"K":    17,
+}
+
+// GetFreeAgentReport returns the best available players, optionally at one
+// position, ranked by rest-of-season projection. ESPN can only sort by season
+// projection, which is at least the rest of the season, so the pool is paged
+// through until nobody further down could still make the list.
+func (a *API) GetFreeAgentReport(position string, week, limit int) (models.FreeAgentReport, error) {
+	season := a.client.Config.Year
+	seasonActual := statSplitID(0, 0, season, 0)
+	seasonProjected := statSplitID(1, 0, season, 0)
+
+	filter := PlayerFilter{
+		Statuses:   []string{statusFreeAgent, statusWaivers},
+		SortBy:     sortAppliedStatTotal,
+		SortStat:   seasonProjected,
+		StatSplits: []string{seasonActual, seasonProjected, statSplitID(1, 1, season, week)},
+		Limit:      playerPageSize,
+	}
+	if week > 1 {
+		filter.StatSplits = append(filter.StatSplits, statSplitID(0, 1, season, week-1))
+	}
+	if position != "" {
+		slotID, ok := positionSlotIDs[position]
+		if !ok {
+			return models.FreeAgentReport{}, fmt.Errorf("unknown position %s", position)
+		}
+		filter.SlotIDs = []int{slotID}
+	}
+
+	report := models.FreeAgentReport{Week: week, Position: position}
+	for {
+		page, err := a.getPlayers(filter, week)
+		if err != nil {
+			return models.FreeAgentReport{}, fmt.Errorf("fetching free agents: %w", err)
+		}
+		for _, entry := range page {
+			report.Players = append(report.Players, buildFreeAgent(entry, week))
+		}
+		sortFreeAgents(report.Players)
+
+		if len(page) < filter.Limit {
+			break
+		}
+		_, lastProjected := seasonPoints(page[len(page)-1].Player)
+		if limit > 0 && len(report.Players) >= limit && lastProjected < report.Players[limit-1].RestOfSeason {
+			break
+		}
+		filter.Offset += len(page)
+	}
+	report.Players = report.Players[:min(len(report.Players), limit)]
+
+	return report, nil
+}
+
+// sortFreeAgents orders free agents by rest-of-season projection, then by
+// this week's projection.
+func sortFreeAgents(players []models.FreeAgent) {
+	sort.SliceStable(players, func(i, j int) bool {
+		if players[i].RestOfSeason != players[j].RestOfSeason {
+			return players[i].RestOfSeason > players[j].RestOfSeason
+		}
+		return players[i].WeekProjected > players[j].WeekProjected
+	})
+}
+
+// seasonPoints returns a player's actual and projected points for the
+// season.
+func seasonPoints(player models.Player) (actual, projected float64) {
+	for _, stat := range player.Stats {
+		if stat.StatSplitTypeID != 0 {
+			continue
+		}
+		switch stat.StatSourceID {
+		case 0:
+			actual = stat.AppliedTotal
+		case 1:
+			projected = stat.AppliedTotal
+		}
+	}
+	return actual, projected
+}
+
+func buildFreeAgent(entry models.PlayerPoolEntry, week int) models.FreeAgent {
+	player := entry.Player
+
+	seasonActual, seasonProjected := seasonPoints(player)
+	_, weekProjected := periodPoints(player, week)
+	lastWeek, _ := periodPoints(player, week-1)
+
+	status := "FA"
+	if entry.Status == statusWaivers {
+		status = "WA"
+	}
+
+	return models.FreeAgent{
+		PlayerID:       player.ID,
+		Name:           player.FullName,
+		Position:       getPositionString(player.DefaultPositionID),
+		ProTeam:        getProTeamString(player.ProTeamID),
+		Status:         status,
+		RestOfSeason:   math.Round(math.Max(seasonProjected-seasonActual, 0)*100) / 100,
+		WeekProjected:  weekProjected,
+		LastWeekPoints: lastWeek,
+		PercentOwned:   player.Ownership.PercentOwned,
+		PercentChange:  player.Ownership.PercentChange,
+	}
+}
@@ -0,0 +1,47 @@
+package espn
+
+import (
+	"time"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// GetRosterStatuses returns the injury status of every rostered player along
+// with whether they are starting and whether their game has kicked off.
+func (a *API) GetRosterStatuses(scoringPeriod int, now time.Time) ([]models.PlayerStatus, error) {
+	leagueResponse, err := a.getRosters(scoringPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := a.GetProGames(scoringPeriod)
+	if err != nil {
+		return nil, err
+	}
+	states := proGameStates(games, now)
+
+	var statuses []models.PlayerStatus
+	for _, team := range leagueResponse.Teams {
+		for _, entry := range team.Roster.Entries {
+			player := entry.PlayerPoolEntry.Player
+			status := player.InjuryStatus
+			if status == "" {
+				status = "ACTIVE"
+			}
+
+			statuses = append(statuses, models.PlayerStatus{
+				PlayerID:     player.ID,
+				Name:         player.FullName,
+				Position:     getPositionString(player.DefaultPositionID),
+				ProTeam:      getProTeamString(player.ProTeamID),
+				TeamID:       team.ID,
+				TeamName:     getTeamName(team.ID),
+				InjuryStatus: status,
+				IsStarter:    isStartingLineup(entry.LineupSlotID),
+				GameStarted:  states[player.ProTeamID] >= gameInProgress,
+			})
+		}
+	}
+
+	return statuses, nil
+}
@@ -3,7 +3,9 @@ package espn
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -40,6 +42,10 @@ func (a *API) GetLeagueMetadata() (*models.LeagueMetadata, error) {
 		FirstWeek:            espnResponse.Status.FirstScoringPeriod,
 		LastWeek:             espnResponse.Status.FinalScoringPeriod,
 		IsActive:             espnResponse.Status.IsActive,
+		RegularSeasonWeeks:   espnResponse.Settings.ScheduleSettings.MatchupPeriodCount,
+		PlayoffTeams:         espnResponse.Settings.ScheduleSettings.PlayoffTeamCount,
+		DraftComplete:        espnResponse.DraftDetail.Drafted,
+		Scoring:              buildScoringSettings(espnResponse.Settings.ScoringSettings),
 		LastUpdated:          time.Now(),
 	}
 
@@ -159,7 +165,45 @@ func getScoreAndProjected(teamScore models.TeamScore) (float64, float64) {
 // 	return false
 // }
 
+// WhoHas finds the player matching playerName among rostered players and
+// free agents alike, or the candidates if more than one matches.
 func (a *API) WhoHas(playerName string, week int) (models.WhoHasResult, error) {
+	teams, players, err := a.searchPool(week)
+	if err != nil {
+		return models.WhoHasResult{}, err
+	}
+
+	return searchPlayers(teams, players, playerName, week), nil
+}
+
+// WhoHasPlayer looks a player up by ESPN player ID, used once a search has
+// been narrowed down to a single candidate.
+func (a *API) WhoHasPlayer(playerID int, week int) (models.WhoHasResult, error) {
+	leagueResponse, err := a.getRosters(week)
+	if err != nil {
+		return models.WhoHasResult{}, err
+	}
+
+	for _, team := range leagueResponse.Teams {
+		for _, entry := range team.Roster.Entries {
+			if entry.PlayerPoolEntry.ID == playerID {
+				return buildWhoHasResult(leagueResponse.Teams, entry.PlayerPoolEntry, week), nil
+			}
+		}
+	}
+
+	players, err := a.getPlayersByID([]int{playerID}, week)
+	if err != nil {
+		return models.WhoHasResult{}, err
+	}
+	if len(players) == 0 {
+		return models.WhoHasResult{Found: false}, nil
+	}
+
+	return buildWhoHasResult(nil, players[0], week), nil
+}
+
+func (a *API) getRosters(week int) (models.LeagueResponse, error) {
 	var leagueResponse models.LeagueResponse
 	endpoint := fmt.Sprintf("/seasons/%s/segments/0/leagues/%s", a.client.Config.Year, a.client.Config.LeagueID)
 	params := map[string]string{
@@ -168,178 +212,253 @@ func (a *API) WhoHas(playerName string, week int) (models.WhoHasResult, error) {
 	}
 
 	if err := a.client.Get(endpoint, params, nil, &leagueResponse); err != nil {
-		return models.WhoHasResult{}, fmt.Errorf("fetching league rosters: %w", err)
+		return models.LeagueResponse{}, fmt.Errorf("fetching league rosters: %w", err)
+	}
+
+	return leagueResponse, nil
+}
+
+// searchPool returns the league's teams and the players name searches look
+// through: every rostered player and the most owned free agents.
+func (a *API) searchPool(week int) ([]models.Team, []models.PlayerPoolEntry, error) {
+	leagueResponse, err := a.getRosters(week)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	var allPlayers []models.PlayerPoolEntry
+	var players []models.PlayerPoolEntry
 	for _, team := range leagueResponse.Teams {
 		for _, entry := range team.Roster.Entries {
-			allPlayers = append(allPlayers, entry.PlayerPoolEntry)
+			players = append(players, entry.PlayerPoolEntry)
 		}
 	}
 
-	result := searchPlayers(leagueResponse.Teams, allPlayers, playerName, week)
-
-	if !result.Found {
-		freeAgentResult, err := a.searchFreeAgents(playerName, week)
-		if err == nil && freeAgentResult.Found {
-			return freeAgentResult, nil
-		}
+	freeAgents, err := a.getFreeAgents(week)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return result, nil
+	return leagueResponse.Teams, append(players, freeAgents...), nil
 }
 
-func (a *API) searchFreeAgents(playerName string, week int) (models.WhoHasResult, error) {
-	endpoint := fmt.Sprintf("/seasons/%s/segments/0/leagues/%s", a.client.Config.Year, a.client.Config.LeagueID)
+// freeAgentSearchPool is how many of the most owned free agents name searches
+// look through. It's one page, so a search costs a single request; players
+// nobody owns anywhere aren't worth the extra pages.
+const freeAgentSearchPool = playerPageSize
 
-	params := map[string]string{
-		"view":            "kona_player_info",
-		"scoringPeriodId": fmt.Sprintf("%d", week),
+// getFreeAgents returns the most owned players on waivers or in the free
+// agent pool, most owned first.
+func (a *API) getFreeAgents(week int) ([]models.PlayerPoolEntry, error) {
+	filter := PlayerFilter{
+		Statuses: []string{statusFreeAgent, statusWaivers},
+		SortBy:   sortPercentOwned,
+		Limit:    freeAgentSearchPool,
 	}
 
-	filters := map[string]interface{}{
-		"players": map[string]interface{}{
-			"offset": 0,
-		},
+	freeAgents, err := a.getPlayers(filter, week)
+	if err != nil {
+		return nil, fmt.Errorf("fetching free agents: %w", err)
 	}
 
-	filtersJSON, err := json.Marshal(filters)
+	return freeAgents, nil
+}
+
+// SearchPlayers returns up to limit players matching playerName, rostered
+// players and free agents alike, best match first.
+func (a *API) SearchPlayers(playerName string, week int, limit int) ([]models.WhoHasResult, error) {
+	teams, players, err := a.searchPool(week)
 	if err != nil {
-		return models.WhoHasResult{}, fmt.Errorf("error marshalling filters: %w", err)
+		return nil, err
 	}
 
-	headers := map[string]string{
-		"x-fantasy-filter": string(filtersJSON),
+	ranked := rankPlayers(players, playerName)
+
+	results := make([]models.WhoHasResult, 0, min(len(ranked), limit))
+	for _, player := range ranked[:min(len(ranked), limit)] {
+		results = append(results, buildWhoHasResult(teams, player, week))
 	}
 
-	var response models.PlayerCardResponse
-	if err := a.client.Get(endpoint, params, headers, &response); err != nil {
-		return models.WhoHasResult{}, fmt.Errorf("fetching free agents: %w", err)
+	return results, nil
+}
+
+func (a *API) getPlayersByID(playerIDs []int, week int) ([]models.PlayerPoolEntry, error) {
+	return a.getPlayers(PlayerFilter{IDs: playerIDs, Limit: len(playerIDs)}, week)
+}
+
+// maxCandidates caps how many options are offered when a search is ambiguous.
+const maxCandidates = 5
+
+// searchPlayers looks playerName up among players. More than one match,
+// even two players with the same full name, is returned as candidates.
+func searchPlayers(teams []models.Team, players []models.PlayerPoolEntry, playerName string, week int) models.WhoHasResult {
+	ranked := rankPlayers(players, playerName)
+
+	if len(ranked) == 0 {
+		return models.WhoHasResult{
+			PlayerName: playerName,
+			Found:      false,
+		}
 	}
 
-	var freeAgents []models.PlayerPoolEntry
-	for _, player := range response.Players {
-		if player.OnTeamID == 0 {
-			freeAgents = append(freeAgents, player)
+	if len(ranked) > 1 {
+		var candidates []models.PlayerCandidate
+		for _, player := range ranked[:min(len(ranked), maxCandidates)] {
+			candidates = append(candidates, models.PlayerCandidate{
+				PlayerID: player.ID,
+				Name:     player.Player.FullName,
+				Position: getPositionString(player.Player.DefaultPositionID),
+				ProTeam:  getProTeamString(player.Player.ProTeamID),
+				TeamName: getTeamName(player.OnTeamID),
+			})
+		}
+
+		return models.WhoHasResult{
+			PlayerName: playerName,
+			Found:      false,
+			Candidates: candidates,
 		}
 	}
 
-	return searchPlayers(nil, freeAgents, playerName, week), nil
+	return buildWhoHasResult(teams, ranked[0], week)
 }
 
-func searchPlayers(teams []models.Team, players []models.PlayerPoolEntry, playerName string, week int) models.WhoHasResult {
+// rankPlayers returns the players matching playerName, best match first. The
+// match strategies are tried from strictest to loosest and only the first
+// one that matches anything is used, so a search for "Allen" returns every
+// Allen but not every name that happens to contain those letters in order.
+func rankPlayers(players []models.PlayerPoolEntry, playerName string) []models.PlayerPoolEntry {
+	search := strings.ToLower(strings.TrimSpace(playerName))
+	searchWords := strings.Fields(search)
+	if len(searchWords) == 0 {
+		return nil
+	}
+
 	var playerNames []string
 	for _, player := range players {
 		playerNames = append(playerNames, player.Player.FullName)
 	}
 
-	matches := fuzzy.FindFold(playerName, playerNames)
-
-	if len(matches) == 0 {
-		matches = fuzzy.Find(playerName, playerNames)
+	// Exact full name.
+	var matches []models.PlayerPoolEntry
+	for _, player := range players {
+		if strings.ToLower(player.Player.FullName) == search {
+			matches = append(matches, player)
+		}
+	}
+	if len(matches) > 0 {
+		sortByOwnership(matches)
+		return matches
 	}
 
-	if len(matches) == 0 {
-		ranked := fuzzy.RankFindFold(playerName, playerNames)
-		if len(ranked) > 0 {
-			matches = append(matches, ranked[0].Target)
+	// Every search word starts one of the name's words, e.g. "allen" or "j allen".
+	for _, player := range players {
+		if wordsPrefixMatch(searchWords, strings.Fields(strings.ToLower(player.Player.FullName))) {
+			matches = append(matches, player)
 		}
 	}
+	if len(matches) > 0 {
+		sortByOwnership(matches)
+		return matches
+	}
 
-	if len(matches) == 0 {
-		lowerSearch := strings.ToLower(playerName)
-		for _, name := range playerNames {
-			lowerName := strings.ToLower(name)
-			if strings.Contains(lowerName, lowerSearch) {
-				matches = append(matches, name)
-				break
+	// Fuzzy match with the search characters appearing in order.
+	ranked := fuzzy.RankFindFold(search, playerNames)
+	if len(ranked) > 0 {
+		sort.SliceStable(ranked, func(i, j int) bool {
+			if ranked[i].Distance != ranked[j].Distance {
+				return ranked[i].Distance < ranked[j].Distance
 			}
+			return players[ranked[i].OriginalIndex].Player.Ownership.PercentOwned >
+				players[ranked[j].OriginalIndex].Player.Ownership.PercentOwned
+		})
+		for _, rank := range ranked {
+			matches = append(matches, players[rank.OriginalIndex])
 		}
+		return matches
 	}
 
-	if len(matches) == 0 {
-		lowerSearch := strings.ToLower(playerName)
-		searchWords := strings.Fields(lowerSearch)
-		
-		for _, name := range playerNames {
-			lowerName := strings.ToLower(name)
-			nameWords := strings.Fields(lowerName)
-			
-			for _, searchWord := range searchWords {
-				for _, nameWord := range nameWords {
-					if len(searchWord) >= 3 && strings.Contains(nameWord, searchWord) {
-						matches = append(matches, name)
-						break
-					}
-					if len(nameWord) >= 3 && strings.Contains(searchWord, nameWord) {
-						matches = append(matches, name)
-						break
-					}
-				}
-				if len(matches) > 0 {
-					break
-				}
-			}
-			if len(matches) > 0 {
-				break
-			}
+	// Partial word match for misspellings, e.g. "mahome" or "kelcee".
+	for _, player := range players {
+		nameWords := strings.Fields(strings.ToLower(player.Player.FullName))
+		if wordsOverlap(searchWords, nameWords) {
+			matches = append(matches, player)
 		}
 	}
+	sortByOwnership(matches)
 
-	if len(matches) > 0 {
-		matchedName := matches[0]
-		
-		var matchedPlayer *models.PlayerPoolEntry
-		for i, player := range players {
-			if player.Player.FullName == matchedName {
-				matchedPlayer = &players[i]
+	return matches
+}
+
+func wordsPrefixMatch(searchWords, nameWords []string) bool {
+	for _, searchWord := range searchWords {
+		found := false
+		for _, nameWord := range nameWords {
+			if strings.HasPrefix(nameWord, searchWord) {
+				found = true
 				break
 			}
 		}
-
-		if matchedPlayer == nil {
-			return models.WhoHasResult{PlayerName: playerName, Found: false}
+		if !found {
+			return false
 		}
+	}
+	return true
+}
 
-		var bestMatchEntry *models.RosterEntry
-		for _, team := range teams {
-			for _, entry := range team.Roster.Entries {
-				if entry.PlayerPoolEntry.ID == matchedPlayer.ID {
-					bestMatchEntry = &entry
-					break
-				}
+func wordsOverlap(searchWords, nameWords []string) bool {
+	for _, searchWord := range searchWords {
+		for _, nameWord := range nameWords {
+			if len(searchWord) >= 3 && strings.Contains(nameWord, searchWord) {
+				return true
 			}
-			if bestMatchEntry != nil {
-				break
+			if len(nameWord) >= 3 && strings.Contains(searchWord, nameWord) {
+				return true
 			}
 		}
+	}
+	return false
+}
 
-		teamName := getTeamName(matchedPlayer.OnTeamID)
-		points, isProjected := getPlayerPoints(*matchedPlayer, week)
+func sortByOwnership(players []models.PlayerPoolEntry) {
+	sort.SliceStable(players, func(i, j int) bool {
+		return players[i].Player.Ownership.PercentOwned > players[j].Player.Ownership.PercentOwned
+	})
+}
 
-		lineupSlot := "Unknown"
+func buildWhoHasResult(teams []models.Team, matchedPlayer models.PlayerPoolEntry, week int) models.WhoHasResult {
+	var bestMatchEntry *models.RosterEntry
+	for _, team := range teams {
+		for _, entry := range team.Roster.Entries {
+			if entry.PlayerPoolEntry.ID == matchedPlayer.ID {
+				bestMatchEntry = &entry
+				break
+			}
+		}
 		if bestMatchEntry != nil {
-			lineupSlot = getLineupSlotString(bestMatchEntry.LineupSlotID)
+			break
 		}
+	}
 
-		return models.WhoHasResult{
-			PlayerName:   matchedPlayer.Player.FullName,
-			TeamName:     teamName,
-			TeamID:       matchedPlayer.OnTeamID,
-			Found:        true,
-			PercentOwned: matchedPlayer.Player.Ownership.PercentOwned,
-			Position:     getPositionString(matchedPlayer.Player.DefaultPositionID),
-			ProTeam:      getProTeamString(matchedPlayer.Player.ProTeamID),
-			Points:       points,
-			IsProjected:  isProjected,
-			LineupSlot:   lineupSlot,
-		}
+	teamName := getTeamName(matchedPlayer.OnTeamID)
+	points, isProjected := getPlayerPoints(matchedPlayer, week)
+
+	lineupSlot := "Unknown"
+	if bestMatchEntry != nil {
+		lineupSlot = getLineupSlotString(bestMatchEntry.LineupSlotID)
 	}
 
 	return models.WhoHasResult{
-		PlayerName: playerName,
-		Found:      false,
+		PlayerID:     matchedPlayer.ID,
+		PlayerName:   matchedPlayer.Player.FullName,
+		TeamName:     teamName,
+		TeamID:       matchedPlayer.OnTeamID,
+		Found:        true,
+		PercentOwned: matchedPlayer.Player.Ownership.PercentOwned,
+		Position:     getPositionString(matchedPlayer.Player.DefaultPositionID),
+		ProTeam:      getProTeamString(matchedPlayer.Player.ProTeamID),
+		Points:       points,
+		IsProjected:  isProjected,
+		LineupSlot:   lineupSlot,
 	}
 }
 
@@ -395,17 +514,17 @@ func getProTeamString(proTeamID int) string {
 	return "Unknown"
 }
 
-func getTeamName(teamID int) string {
-	teams := map[int]string{
-		2: "Coach Dad",
-		3: "Stairway to Evans",
-		6: "Team InvincibleVince",
-		5: "Beyond Cursed",
-		1: "I Weigh Less Than Omar",
-		4: "UGF Pandas",
-	}
+var teamNames = map[int]string{
+	2: "Coach Dad",
+	3: "Stairway to Evans",
+	6: "Team InvincibleVince",
+	5: "Beyond Cursed",
+	1: "I Weigh Less Than Omar",
+	4: "UGF Pandas",
+}
 
-	name, ok := teams[teamID]
+func getTeamName(teamID int) string {
+	name, ok := teamNames[teamID]
 	if !ok {
 		return "Unknown"
 	}
@@ -470,62 +589,117 @@ func isPlayerToMonitor(status string) bool {
 	return status == "QUESTIONABLE" || status == "DOUBTFUL" || status == "OUT"
 }
 
-func (a *API) GetTeamRoster(teamName string, week int) (models.TeamRoster, error) {
-	var leagueResponse models.LeagueResponse
-	var scoreboardResponse models.ScoreboardResponse
-	endpoint := fmt.Sprintf("/seasons/%s/segments/0/leagues/%s", a.client.Config.Year, a.client.Config.LeagueID)
-	params := map[string]string{
-		"view":            "mRoster",
-		"scoringPeriodId": fmt.Sprintf("%d", week),
+// GetTeamRoster returns the roster of the fantasy team matching teamName,
+// with point breakdowns from settings. Empty settings leave them out.
+func (a *API) GetTeamRoster(teamName string, week int, settings models.ScoringSettings) (models.TeamRoster, error) {
+	leagueResponse, err := a.getRosters(week)
+	if err != nil {
+		return models.TeamRoster{}, err
 	}
 
-	if err := a.client.Get(endpoint, params, nil, &leagueResponse); err != nil {
-		return models.TeamRoster{}, fmt.Errorf("fetching league rosters: %w", err)
+	matches := matchTeams(leagueResponse.Teams, teamName)
+
+	if len(matches) == 0 {
+		return models.TeamRoster{}, fmt.Errorf("team not found: %s", teamName)
 	}
 
-	filters := map[string]interface{}{
-		"schedule": map[string]interface{}{
-			"filterMatchupPeriodIds": map[string]interface{}{
-				"value": []int{week},
-			},
-		},
+	if len(matches) > 1 {
+		var candidates []models.TeamCandidate
+		for _, team := range matches[:min(len(matches), maxCandidates)] {
+			candidates = append(candidates, models.TeamCandidate{
+				TeamID:   team.ID,
+				TeamName: getTeamName(team.ID),
+			})
+		}
+		return models.TeamRoster{Candidates: candidates}, nil
 	}
 
-	filtersJSON, err := json.Marshal(filters)
+	return a.buildTeamRoster(matches[0], week, settings)
+}
+
+// GetTeamRosterByID returns the roster of the fantasy team with the given ID.
+func (a *API) GetTeamRosterByID(teamID int, week int, settings models.ScoringSettings) (models.TeamRoster, error) {
+	leagueResponse, err := a.getRosters(week)
 	if err != nil {
-		return models.TeamRoster{}, fmt.Errorf("error marshalling filters: %w", err)
+		return models.TeamRoster{}, err
 	}
 
-	headers := map[string]string{
-		"x-fantasy-filter": string(filtersJSON),
+	for _, team := range leagueResponse.Teams {
+		if team.ID == teamID {
+			return a.buildTeamRoster(team, week, settings)
+		}
 	}
 
-	if err := a.client.Get(endpoint, map[string]string{"view": "mScoreboard"}, headers, &scoreboardResponse); err != nil {
-		return models.TeamRoster{}, fmt.Errorf("fetching scoreboard: %w", err)
+	return models.TeamRoster{}, fmt.Errorf("team not found: %d", teamID)
+}
+
+// matchTeams returns the teams whose name resembles teamName, most similar
+// first. An exact (case-insensitive) name match is returned on its own.
+func matchTeams(teams []models.Team, teamName string) []models.Team {
+	byID := make(map[int]models.Team, len(teams))
+	ids := make([]int, len(teams))
+	for i, team := range teams {
+		byID[team.ID] = team
+		ids[i] = team.ID
 	}
 
-	var bestMatch *models.Team
-	bestScore := -1
+	matchIDs := matchTeamIDs(ids, teamName)
+	matches := make([]models.Team, len(matchIDs))
+	for i, id := range matchIDs {
+		matches[i] = byID[id]
+	}
+	return matches
+}
+
+// MatchTeamIDs returns the IDs of the league's teams whose name resembles
+// teamName, most similar first, the same way /team finds a team.
+func (a *API) MatchTeamIDs(teamName string) []int {
+	return matchTeamIDs(slices.Sorted(maps.Keys(teamNames)), teamName)
+}
+
+// matchTeamIDs returns the IDs of the teams whose name resembles teamName,
+// most similar first. An exact (case-insensitive) name match is returned on
+// its own.
+func matchTeamIDs(ids []int, teamName string) []int {
+	search := strings.ToLower(strings.TrimSpace(teamName))
 	threshold := 0.6
 
-	for i, team := range leagueResponse.Teams {
-		currentTeamName := getTeamName(team.ID)
-		distance := fuzzy.LevenshteinDistance(strings.ToLower(teamName), strings.ToLower(currentTeamName))
-		maxLen := float64(max(len(teamName), len(currentTeamName)))
+	type scoredTeam struct {
+		id         int
+		similarity float64
+	}
+
+	var scored []scoredTeam
+	for _, id := range ids {
+		currentTeamName := strings.ToLower(getTeamName(id))
+		if currentTeamName == search {
+			return []int{id}
+		}
+
+		distance := fuzzy.LevenshteinDistance(search, currentTeamName)
+		maxLen := float64(max(len(search), len(currentTeamName)))
 		similarity := 1 - float64(distance)/maxLen
 
-		if similarity > threshold && (bestScore == -1 || similarity > float64(bestScore)) {
-			bestScore = int(similarity * 100)
-			bestMatch = &leagueResponse.Teams[i]
+		if similarity > threshold || strings.Contains(currentTeamName, search) {
+			scored = append(scored, scoredTeam{id: id, similarity: similarity})
 		}
 	}
 
-	if bestMatch == nil {
-		return models.TeamRoster{}, fmt.Errorf("team not found: %s", teamName)
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].similarity > scored[j].similarity
+	})
+
+	matches := make([]int, len(scored))
+	for i, s := range scored {
+		matches[i] = s.id
 	}
+	return matches
+}
 
+func (a *API) buildTeamRoster(team models.Team, week int, settings models.ScoringSettings) (models.TeamRoster, error) {
 	roster := models.TeamRoster{
-		TeamName: getTeamName(bestMatch.ID),
+		TeamID:   team.ID,
+		TeamName: getTeamName(team.ID),
 		Players:  make([]models.RosterPlayer, 0),
 	}
 
@@ -537,11 +711,12 @@ func (a *API) GetTeamRoster(teamName string, week int) (models.TeamRoster, error
 		return models.TeamRoster{}, fmt.Errorf("fetching pro schedule: %w", err)
 	}
 
-	for _, entry := range bestMatch.Roster.Entries {
+	for _, entry := range team.Roster.Entries {
 		player := entry.PlayerPoolEntry.Player
 		points, _ := getPlayerPoints(entry.PlayerPoolEntry, week)
 
 		pointsDisplay := "TBD"
+		var breakdown []models.StatLineItem
 		if entry.LineupSlotID == 21 || player.InjuryStatus == "INJURY_RESERVE" {
 			pointsDisplay = "IR"
 		} else if byeWeek, ok := byeWeeks[player.ProTeamID]; ok && byeWeek == week {
@@ -554,6 +729,7 @@ func (a *API) GetTeamRoster(teamName string, week int) (models.TeamRoster, error
 					if stat.StatSourceID == 0 {
 						hasActualStats = true
 						pointsDisplay = fmt.Sprintf("%.2f", stat.AppliedTotal)
+						_, breakdown = calculatePoints(settings, player, stat)
 						break
 					}
 				}
@@ -572,6 +748,7 @@ func (a *API) GetTeamRoster(teamName string, week int) (models.TeamRoster, error
 			IsStarter:    isStartingLineup(entry.LineupSlotID),
 			LineupSlot:   getLineupSlotString(entry.LineupSlotID),
 			InjuryStatus: player.InjuryStatus,
+			Breakdown:    breakdown,
 		}
 
 		if isStartingLineup(entry.LineupSlotID) {
@@ -626,13 +803,23 @@ func getLineupSlotString(slotID int) string {
 }
 
 type ProTeamInfo struct {
-	ID      int    `json:"id"`
-	Abbrev  string `json:"abbrev"`
-	ByeWeek int    `json:"byeWeek"`
-	Name    string `json:"name"`
+	ID                      int                  `json:"id"`
+	Abbrev                  string               `json:"abbrev"`
+	ByeWeek                 int                  `json:"byeWeek"`
+	Name                    string               `json:"name"`
+	ProGamesByScoringPeriod map[string][]ProGame `json:"proGamesByScoringPeriod"`
 }
 
-func (a *API) GetProSchedule() (map[int]int, error) {
+type ProGame struct {
+	ID              int   `json:"id"`
+	Date            int64 `json:"date"`
+	HomeProTeamID   int   `json:"homeProTeamId"`
+	AwayProTeamID   int   `json:"awayProTeamId"`
+	ScoringPeriodID int   `json:"scoringPeriodId"`
+	StartTimeTBD    bool  `json:"startTimeTBD"`
+}
+
+func (a *API) getProTeams() ([]ProTeamInfo, error) {
 	var scheduleResponse struct {
 		Settings struct {
 			ProTeams []ProTeamInfo `json:"proTeams"`
@@ -648,8 +835,17 @@ func (a *API) GetProSchedule() (map[int]int, error) {
 		return nil, fmt.Errorf("fetching pro schedule: %w", err)
 	}
 
+	return scheduleResponse.Settings.ProTeams, nil
+}
+
+func (a *API) GetProSchedule() (map[int]int, error) {
+	proTeams, err := a.getProTeams()
+	if err != nil {
+		return nil, err
+	}
+
 	byeWeeks := make(map[int]int)
-	for _, team := range scheduleResponse.Settings.ProTeams {
+	for _, team := range proTeams {
 		if team.ByeWeek > 0 {
 			byeWeeks[team.ID] = team.ByeWeek
 		}
@@ -657,3 +853,44 @@ func (a *API) GetProSchedule() (map[int]int, error) {
 
 	return byeWeeks, nil
 }
+
+// GetProGames returns the NFL games of a scoring period ordered by kickoff.
+func (a *API) GetProGames(scoringPeriod int) ([]models.ProGame, error) {
+	proTeams, err := a.getProTeams()
+	if err != nil {
+		return nil, err
+	}
+
+	return proGamesForPeriod(proTeams, scoringPeriod), nil
+}
+
+func proGamesForPeriod(proTeams []ProTeamInfo, scoringPeriod int) []models.ProGame {
+	// Every game is listed under both of its teams.
+	seen := make(map[int]bool)
+	var games []models.ProGame
+
+	for _, team := range proTeams {
+		for _, game := range team.ProGamesByScoringPeriod[fmt.Sprintf("%d", scoringPeriod)] {
+			if seen[game.ID] {
+				continue
+			}
+			seen[game.ID] = true
+
+			games = append(games, models.ProGame{
+				ID:            game.ID,
+				HomeProTeamID: game.HomeProTeamID,
+				AwayProTeamID: game.AwayProTeamID,
+				HomeProTeam:   getProTeamString(game.HomeProTeamID),
+				AwayProTeam:   getProTeamString(game.AwayProTeamID),
+				Kickoff:       time.UnixMilli(game.Date),
+				StartTimeTBD:  game.StartTimeTBD,
+			})
+		}
+	}
+
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].Kickoff.Before(games[j].Kickoff)
+	})
+
+	return games
+}
@@ -0,0 +1,157 @@
+package espn
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// gameDuration is how long an NFL game is assumed to last after kickoff.
+// The schedule only has kickoff times, so a game counts as finished once
+// this much time has passed.
+const gameDuration = 3*time.Hour + 30*time.Minute
+
+type gameState int
+
+const (
+	gameNone gameState = iota // bye week or no game on the schedule
+	gameScheduled
+	gameInProgress
+	gameFinal
+)
+
+func proGameStates(games []models.ProGame, now time.Time) map[int]gameState {
+	states := make(map[int]gameState)
+	for _, game := range games {
+		var state gameState
+		switch {
+		case now.Before(game.Kickoff):
+			state = gameScheduled
+		case now.Before(game.Kickoff.Add(gameDuration)):
+			state = gameInProgress
+		default:
+			state = gameFinal
+		}
+		states[game.HomeProTeamID] = state
+		states[game.AwayProTeamID] = state
+	}
+	return states
+}
+
+// GetLiveScoreboard returns the week's matchups along with how many of each
+// team's starters have yet to play or are playing right now.
+func (a *API) GetLiveScoreboard(week, scoringPeriod int, now time.Time) (models.LiveScoreboard, error) {
+	var scoreboardResponse models.ScoreboardResponse
+	endpoint := fmt.Sprintf("/seasons/%s/segments/0/leagues/%s", a.client.Config.Year, a.client.Config.LeagueID)
+
+	params := map[string]string{
+		"view":            "mScoreboard",
+		"scoringPeriodId": fmt.Sprintf("%d", scoringPeriod),
+	}
+
+	filters := map[string]interface{}{
+		"schedule": map[string]interface{}{
+			"filterMatchupPeriodIds": map[string]interface{}{
+				"value": []int{week},
+			},
+		},
+	}
+
+	filtersJSON, err := json.Marshal(filters)
+	if err != nil {
+		return models.LiveScoreboard{}, fmt.Errorf("error marshalling filters: %w", err)
+	}
+
+	headers := map[string]string{
+		"x-fantasy-filter": string(filtersJSON),
+	}
+
+	if err := a.client.Get(endpoint, params, headers, &scoreboardResponse); err != nil {
+		return models.LiveScoreboard{}, fmt.Errorf("fetching live scores: %w", err)
+	}
+
+	games, err := a.GetProGames(scoringPeriod)
+	if err != nil {
+		return models.LiveScoreboard{}, err
+	}
+	states := proGameStates(games, now)
+
+	scoreboard := models.LiveScoreboard{
+		Week:          week,
+		ScoringPeriod: scoringPeriod,
+		UpdatedAt:     now,
+	}
+
+	for _, match := range scoreboardResponse.Schedule {
+		home := liveTeamScore(match.Home, scoringPeriod, states)
+		away := liveTeamScore(match.Away, scoringPeriod, states)
+
+		scoreboard.Matchups = append(scoreboard.Matchups, models.LiveMatchup{
+			MatchID:     match.ID,
+			Home:        home,
+			Away:        away,
+			IsCompleted: match.Winner != "UNDECIDED",
+		})
+
+		if home.PlayersInProgress > 0 || away.PlayersInProgress > 0 {
+			scoreboard.GamesInProgress = true
+		}
+		if home.PlayersYetToPlay > 0 || away.PlayersYetToPlay > 0 {
+			scoreboard.GamesRemaining = true
+		}
+	}
+
+	return scoreboard, nil
+}
+
+func liveTeamScore(teamScore models.TeamScore, scoringPeriod int, states map[int]gameState) models.LiveTeamScore {
+	score, projected := getScoreAndProjected(teamScore)
+
+	live := models.LiveTeamScore{
+		TeamID:    teamScore.TeamID,
+		TeamName:  getTeamName(teamScore.TeamID),
+		Score:     score,
+		Projected: projected,
+	}
+
+	for _, entry := range teamScore.RosterForCurrentScoringPeriod.Entries {
+		if !isStartingLineup(entry.LineupSlotID) {
+			continue
+		}
+
+		player := entry.PlayerPoolEntry.Player
+		actual, projected := periodPoints(player, scoringPeriod)
+
+		switch states[player.ProTeamID] {
+		case gameScheduled:
+			live.PlayersYetToPlay++
+			live.RemainingProjected += projected
+		case gameInProgress:
+			live.PlayersInProgress++
+			live.RemainingProjected += math.Max(projected-actual, 0)
+		}
+	}
+
+	live.RemainingProjected = math.Round(live.RemainingProjected*100) / 100
+	return live
+}
+
+// periodPoints returns a player's actual and projected points for a scoring period.
+func periodPoints(player models.Player, scoringPeriod int) (float64, float64) {
+	var actual, projected float64
+	for _, stat := range player.Stats {
+		if stat.ScoringPeriodID != scoringPeriod {
+			continue
+		}
+		switch stat.StatSourceID {
+		case 0:
+			actual = stat.AppliedTotal
+		case 1:
+			projected = stat.AppliedTotal
+		}
+	}
+	return actual, projected
+}
@@ -0,0 +1,165 @@
+package espn
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// Player pool statuses accepted by PlayerFilter.Statuses.
+const (
+	statusFreeAgent = "FREEAGENT"
+	statusWaivers   = "WAIVERS"
+)
+
+// Values accepted by PlayerFilter.SortBy.
+const (
+	sortPercentOwned     = "sortPercOwned"
+	sortAppliedStatTotal = "sortAppliedStatTotal"
+)
+
+// playerPageSize is how many players are requested per kona_player_info page.
+const playerPageSize = 250
+
+// PlayerFilter builds the x-fantasy-filter header for kona_player_info.
+// Zero values leave the corresponding filter out.
+type PlayerFilter struct {
+	IDs      []int
+	Statuses []string
+	SlotIDs  []int
+	// SortBy orders the pool server side, descending. With
+	// sortAppliedStatTotal, SortStat picks which stat split to sort on,
+	// e.g. "102025" for the 2025 season projection.
+	SortBy   string
+	SortStat string
+	// StatSplits limits the returned stats to the given split IDs; see
+	// statSplitID.
+	StatSplits []string
+	// StatSources and StatSplitTypes return every stat split of the given
+	// sources (0 actual, 1 projected) and types (0 season, 1 scoring period).
+	StatSources    []int
+	StatSplitTypes []int
+	Limit          int
+	Offset         int
+}
+
+func (f PlayerFilter) header() (string, error) {
+	players := map[string]interface{}{}
+
+	if len(f.IDs) > 0 {
+		players["filterIds"] = map[string]interface{}{"value": f.IDs}
+	}
+	if len(f.Statuses) > 0 {
+		players["filterStatus"] = map[string]interface{}{"value": f.Statuses}
+	}
+	if len(f.SlotIDs) > 0 {
+		players["filterSlotIds"] = map[string]interface{}{"value": f.SlotIDs}
+	}
+	if f.SortBy != "" {
+		sort := map[string]interface{}{"sortPriority": 1, "sortAsc": false}
+		if f.SortStat != "" {
+			sort["value"] = f.SortStat
+		}
+		players[f.SortBy] = sort
+	}
+	if len(f.StatSplits) > 0 {
+		players["filterStatsForTopScoredPeriodIds"] = map[string]interface{}{
+			"value":           len(f.StatSplits),
+			"additionalValue": f.StatSplits,
+		}
+	}
+	if len(f.StatSources) > 0 {
+		players["filterStatsForSourceIds"] = map[string]interface{}{"value": f.StatSources}
+	}
+	if len(f.StatSplitTypes) > 0 {
+		players["filterStatsForSplitTypeIds"] = map[string]interface{}{"value": f.StatSplitTypes}
+	}
+	if f.Limit > 0 {
+		players["limit"] = f.Limit
+	}
+	players["offset"] = f.Offset
+
+	filtersJSON, err := json.Marshal(map[string]interface{}{"players": players})
+	if err != nil {
+		return "", fmt.Errorf("error marshalling filters: %w", err)
+	}
+	return string(filtersJSON), nil
+}
+
+// statSplitID builds the ID ESPN uses for a stat split: the stat source
+// (0 actual, 1 projected), the split type (0 season, 1 scoring period), the
+// season and, for scoring period splits, the period.
+func statSplitID(source, splitType int, season string, scoringPeriod int) string {
+	id := fmt.Sprintf("%d%d%s", source, splitType, season)
+	if splitType == 1 {
+		id += fmt.Sprintf("%d", scoringPeriod)
+	}
+	return id
+}
+
+// getPlayers fetches one page of the player pool.
+func (a *API) getPlayers(filter PlayerFilter, week int) ([]models.PlayerPoolEntry, error) {
+	endpoint := fmt.Sprintf("/seasons/%s/segments/0/leagues/%s", a.client.Config.Year, a.client.Config.LeagueID)
+
+	params := map[string]string{
+		"view":            "kona_player_info",
+		"scoringPeriodId": fmt.Sprintf("%d", week),
+	}
+
+	filterHeader, err := filter.header()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{
+		"x-fantasy-filter": filterHeader,
+	}
+
+	var response models.PlayerCardResponse
+	if err := a.client.Get(endpoint, params, headers, &response); err != nil {
+		return nil, fmt.Errorf("fetching players: %w", err)
+	}
+
+	return response.Players, nil
+}
+
+// GetPlayerStates returns who rosters each player, whether unrostered
+// players are on waivers, and their injury status.
+func (a *API) GetPlayerStates(playerIDs []int, week int) ([]models.PlayerState, error) {
+	players, err := a.getPlayersByID(playerIDs, week)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]models.PlayerState, 0, len(players))
+	for _, entry := range players {
+		player := entry.Player
+
+		state := models.PlayerState{
+			PlayerID:     player.ID,
+			Name:         player.FullName,
+			Position:     getPositionString(player.DefaultPositionID),
+			ProTeam:      getProTeamString(player.ProTeamID),
+			TeamID:       entry.OnTeamID,
+			InjuryStatus: player.InjuryStatus,
+		}
+		if state.InjuryStatus == "" {
+			state.InjuryStatus = "ACTIVE"
+		}
+
+		switch {
+		case entry.OnTeamID != 0:
+			state.Availability = models.Rostered
+			state.TeamName = getTeamName(entry.OnTeamID)
+		case entry.Status == statusWaivers:
+			state.Availability = models.OnWaivers
+		default:
+			state.Availability = models.FreeAgentPool
+		}
+
+		states = append(states, state)
+	}
+
+	return states, nil
+}
@@ -0,0 +1,90 @@
+package espn
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+const winnersBracket = "WINNERS_BRACKET"
+
+// GetPlayoffBracket returns the winners bracket games of the given matchup
+// periods, one round per period, with each team's playoff seed.
+func (a *API) GetPlayoffBracket(weeks []int) (models.PlayoffBracketReport, error) {
+	standings, err := a.GetStandings()
+	if err != nil {
+		return models.PlayoffBracketReport{}, err
+	}
+	seeds := make(map[int]int, len(standings))
+	for _, standing := range standings {
+		seeds[standing.TeamID] = standing.PlayoffSeed
+	}
+
+	var scoreboardResponse models.ScoreboardResponse
+	endpoint := fmt.Sprintf("/seasons/%s/segments/0/leagues/%s", a.client.Config.Year, a.client.Config.LeagueID)
+	params := map[string]string{
+		"view": "mScoreboard",
+	}
+
+	filters := map[string]interface{}{
+		"schedule": map[string]interface{}{
+			"filterMatchupPeriodIds": map[string]interface{}{
+				"value": weeks,
+			},
+		},
+	}
+
+	filtersJSON, err := json.Marshal(filters)
+	if err != nil {
+		return models.PlayoffBracketReport{}, fmt.Errorf("error marshalling filters: %w", err)
+	}
+
+	headers := map[string]string{
+		"x-fantasy-filter": string(filtersJSON),
+	}
+
+	if err := a.client.Get(endpoint, params, headers, &scoreboardResponse); err != nil {
+		return models.PlayoffBracketReport{}, fmt.Errorf("fetching playoff bracket: %w", err)
+	}
+
+	rounds := make(map[int][]models.PlayoffMatchup)
+	for _, match := range scoreboardResponse.Schedule {
+		if match.PlayoffTierType != winnersBracket {
+			continue
+		}
+
+		homeScore, _ := getScoreAndProjected(match.Home)
+		matchup := models.PlayoffMatchup{
+			HomeTeam:  getTeamName(match.Home.TeamID),
+			HomeSeed:  seeds[match.Home.TeamID],
+			HomeScore: homeScore,
+		}
+		if match.Away.TeamID != 0 {
+			matchup.AwayTeam = getTeamName(match.Away.TeamID)
+			matchup.AwaySeed = seeds[match.Away.TeamID]
+			matchup.AwayScore, _ = getScoreAndProjected(match.Away)
+		}
+
+		switch match.Winner {
+		case "HOME":
+			matchup.Winner = matchup.HomeTeam
+		case "AWAY":
+			matchup.Winner = matchup.AwayTeam
+		}
+
+		rounds[match.MatchupPeriodID] = append(rounds[match.MatchupPeriodID], matchup)
+	}
+
+	var report models.PlayoffBracketReport
+	for _, week := range weeks {
+		matchups := rounds[week]
+		sort.Slice(matchups, func(i, j int) bool {
+			return matchups[i].HomeSeed < matchups[j].HomeSeed
+		})
+		report.Rounds = append(report.Rounds, models.PlayoffRound{Week: week, Matchups: matchups})
+	}
+
+	return report, nil
+}
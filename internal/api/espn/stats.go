@@ -0,0 +1,347 @@
+package espn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// statCategories names the ESPN stat IDs that show up in stat lines. The
+// order is the order categories are listed in.
+var statCategories = []struct {
+	id    int
+	label string
+}{
+	{1, "Completions"},
+	{0, "Pass Attempts"},
+	{3, "Passing Yards"},
+	{4, "Passing TDs"},
+	{20, "Interceptions"},
+	{19, "Passing 2PT"},
+	{17, "300-399 Yd Passing Game"},
+	{18, "400+ Yd Passing Game"},
+	{23, "Rushing Attempts"},
+	{24, "Rushing Yards"},
+	{25, "Rushing TDs"},
+	{26, "Rushing 2PT"},
+	{37, "100-199 Yd Rushing Game"},
+	{38, "200+ Yd Rushing Game"},
+	{58, "Targets"},
+	{53, "Receptions"},
+	{42, "Receiving Yards"},
+	{43, "Receiving TDs"},
+	{44, "Receiving 2PT"},
+	{56, "100-199 Yd Receiving Game"},
+	{57, "200+ Yd Receiving Game"},
+	{72, "Fumbles Lost"},
+	{74, "FG Made 50+"},
+	{77, "FG Made 40-49"},
+	{80, "FG Made 0-39"},
+	{85, "FG Missed"},
+	{86, "XP Made"},
+	{88, "XP Missed"},
+	{99, "Sacks"},
+	{95, "Interceptions (DEF)"},
+	{96, "Fumbles Recovered"},
+	{98, "Safeties"},
+	{97, "Blocked Kicks"},
+	{94, "Defensive TDs"},
+	{101, "Kick Return TDs"},
+	{102, "Punt Return TDs"},
+	{120, "Points Allowed"},
+	{127, "Yards Allowed"},
+}
+
+// bonusCategories names the ESPN stat IDs that count thresholds reached. They
+// are 1 when the threshold was reached and 0 otherwise.
+var bonusCategories = []struct {
+	id    int
+	label string
+}{
+	{15, "40+ Yd Passing TD Bonus"},
+	{16, "50+ Yd Passing TD Bonus"},
+	{35, "40+ Yd Rushing TD Bonus"},
+	{36, "50+ Yd Rushing TD Bonus"},
+	{45, "40+ Yd Receiving TD Bonus"},
+	{46, "50+ Yd Receiving TD Bonus"},
+	{89, "0 Points Allowed"},
+	{90, "1-6 Points Allowed"},
+	{91, "7-13 Points Allowed"},
+	{92, "14-17 Points Allowed"},
+	{121, "18-21 Points Allowed"},
+	{122, "22-27 Points Allowed"},
+	{123, "28-34 Points Allowed"},
+	{124, "35-45 Points Allowed"},
+	{125, "46+ Points Allowed"},
+	{128, "0-99 Yards Allowed"},
+	{129, "100-199 Yards Allowed"},
+	{130, "200-299 Yards Allowed"},
+	{131, "300-349 Yards Allowed"},
+	{132, "350-399 Yards Allowed"},
+	{133, "400-449 Yards Allowed"},
+	{134, "450-499 Yards Allowed"},
+	{135, "500-549 Yards Allowed"},
+	{136, "550+ Yards Allowed"},
+}
+
+// The game bonuses are in statCategories so they keep their place next to
+// the yardage they reward.
+var gameBonuses = map[int]bool{17: true, 18: true, 37: true, 38: true, 56: true, 57: true}
+
+// positionSlots maps a player's default position to the lineup slot ESPN
+// keys scoring overrides by.
+var positionSlots = map[int]int{1: 0, 2: 2, 3: 4, 4: 6, 5: 17, 16: 16}
+
+// buildScoringSettings reads the league's scoring from mSettings.
+func buildScoringSettings(response models.ScoringSettingsResponse) models.ScoringSettings {
+	settings := models.ScoringSettings{Items: make(map[int]models.ScoringItem)}
+
+	for _, item := range response.ScoringItems {
+		scoringItem := models.ScoringItem{
+			StatID: item.StatID,
+			Label:  fmt.Sprintf("Stat %d", item.StatID),
+			Points: item.Points,
+			Bonus:  gameBonuses[item.StatID],
+		}
+		for slot, points := range item.PointsOverrides {
+			slotID, err := strconv.Atoi(slot)
+			if err != nil {
+				continue
+			}
+			if scoringItem.SlotOverrides == nil {
+				scoringItem.SlotOverrides = make(map[int]float64)
+			}
+			scoringItem.SlotOverrides[slotID] = points
+		}
+		settings.Items[item.StatID] = scoringItem
+	}
+
+	// Known stats first, in display order, then anything else by ID.
+	seen := make(map[int]bool)
+	addInOrder := func(statID int, label string, bonus bool) {
+		item, ok := settings.Items[statID]
+		if !ok {
+			return
+		}
+		item.Label = label
+		item.Bonus = item.Bonus || bonus
+		settings.Items[statID] = item
+		settings.Order = append(settings.Order, statID)
+		seen[statID] = true
+	}
+	for _, category := range statCategories {
+		addInOrder(category.id, category.label, false)
+	}
+	for _, category := range bonusCategories {
+		addInOrder(category.id, category.label, true)
+	}
+
+	var rest []int
+	for statID := range settings.Items {
+		if !seen[statID] {
+			rest = append(rest, statID)
+		}
+	}
+	sort.Ints(rest)
+	settings.Order = append(settings.Order, rest...)
+
+	return settings
+}
+
+// rawStats converts a stat split's raw stats to stat ID keys.
+func rawStats(stat models.Stat) map[int]float64 {
+	stats := make(map[int]float64, len(stat.RawStats))
+	for key, value := range stat.RawStats {
+		statID, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		stats[statID] = value
+	}
+	return stats
+}
+
+// calculatePoints recomputes a player's points for a stat split with the
+// league's scoring.
+func calculatePoints(settings models.ScoringSettings, player models.Player, stat models.Stat) (float64, []models.StatLineItem) {
+	return settings.Calculate(rawStats(stat), positionSlots[player.DefaultPositionID])
+}
+
+// GetPlayerGameLog returns a player's actual and projected fantasy points
+// for every week up to week, and their stat line for week scored with
+// settings. Empty settings leave the points per stat out.
+func (a *API) GetPlayerGameLog(playerID, week int, settings models.ScoringSettings) (models.PlayerGameLog, error) {
+	filter := PlayerFilter{
+		IDs:            []int{playerID},
+		StatSources:    []int{0, 1},
+		StatSplitTypes: []int{1},
+	}
+
+	players, err := a.getPlayers(filter, week)
+	if err != nil {
+		return models.PlayerGameLog{}, fmt.Errorf("fetching player stats: %w", err)
+	}
+	if len(players) == 0 {
+		return models.PlayerGameLog{}, fmt.Errorf("player #%d not found", playerID)
+	}
+
+	entry := players[0]
+	player := entry.Player
+
+	gameLog := models.PlayerGameLog{
+		PlayerID: player.ID,
+		Name:     player.FullName,
+		Position: getPositionString(player.DefaultPositionID),
+		ProTeam:  getProTeamString(player.ProTeamID),
+		Week:     week,
+	}
+	if entry.OnTeamID != 0 {
+		gameLog.TeamName = getTeamName(entry.OnTeamID)
+	}
+
+	actual := make(map[int]models.Stat)
+	projected := make(map[int]models.Stat)
+	for _, stat := range player.Stats {
+		if stat.StatSplitTypeID != 1 || stat.ScoringPeriodID == 0 {
+			continue
+		}
+		switch stat.StatSourceID {
+		case 0:
+			actual[stat.ScoringPeriodID] = stat
+		case 1:
+			projected[stat.ScoringPeriodID] = stat
+		}
+	}
+
+	for period := 1; period <= week; period++ {
+		actualStat, played := actual[period]
+		gameLog.Weeks = append(gameLog.Weeks, models.PlayerWeek{
+			Week:      period,
+			Points:    actualStat.AppliedTotal,
+			Projected: projected[period].AppliedTotal,
+			Played:    played,
+		})
+		if played {
+			gameLog.SeasonPoints += actualStat.AppliedTotal
+		}
+	}
+	gameLog.SeasonPoints = math.Round(gameLog.SeasonPoints*100) / 100
+
+	stat, ok := actual[week]
+	if !ok {
+		stat, ok = projected[week]
+		gameLog.StatLineProjected = ok
+	}
+	if ok {
+		gameLog.StatLinePoints, gameLog.StatLine = statLine(settings, player, stat)
+		gameLog.ESPNPoints = stat.AppliedTotal
+		if len(settings.Items) == 0 {
+			gameLog.StatLinePoints = stat.AppliedTotal
+		}
+	}
+
+	return gameLog, nil
+}
+
+// statLine lists a stat split's points per category as the league's scoring
+// awards them, plus the stats that are worth nothing but still tell the story,
+// like targets and pass attempts.
+func statLine(settings models.ScoringSettings, player models.Player, stat models.Stat) (float64, []models.StatLineItem) {
+	total, breakdown := calculatePoints(settings, player, stat)
+
+	scored := make(map[string]bool, len(breakdown))
+	for _, item := range breakdown {
+		scored[item.Category] = true
+	}
+
+	stats := rawStats(stat)
+	var items []models.StatLineItem
+	for _, category := range statCategories {
+		if !scored[category.label] && stats[category.id] != 0 && !gameBonuses[category.id] {
+			items = append(items, models.StatLineItem{Category: category.label, Value: stats[category.id]})
+		}
+	}
+
+	return total, append(breakdown, items...)
+}
+
+// scoreTolerance absorbs rounding when comparing recalculated totals.
+const scoreTolerance = 0.05
+
+// ValidateScores recalculates every team's week total from its starters'
+// stats with settings and returns the teams whose ESPN total differs.
+func (a *API) ValidateScores(week int, settings models.ScoringSettings) ([]models.ScoreDiscrepancy, error) {
+	if len(settings.Items) == 0 {
+		return nil, errors.New("no scoring settings to validate with")
+	}
+
+	var scoreboardResponse models.ScoreboardResponse
+	endpoint := fmt.Sprintf("/seasons/%s/segments/0/leagues/%s", a.client.Config.Year, a.client.Config.LeagueID)
+
+	params := map[string]string{
+		"view":            "mScoreboard",
+		"scoringPeriodId": fmt.Sprintf("%d", week),
+	}
+
+	filters := map[string]interface{}{
+		"schedule": map[string]interface{}{
+			"filterMatchupPeriodIds": map[string]interface{}{
+				"value": []int{week},
+			},
+		},
+	}
+
+	filtersJSON, err := json.Marshal(filters)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling filters: %w", err)
+	}
+
+	headers := map[string]string{
+		"x-fantasy-filter": string(filtersJSON),
+	}
+
+	if err := a.client.Get(endpoint, params, headers, &scoreboardResponse); err != nil {
+		return nil, fmt.Errorf("fetching scores: %w", err)
+	}
+
+	var discrepancies []models.ScoreDiscrepancy
+	for _, match := range scoreboardResponse.Schedule {
+		for _, team := range []models.TeamScore{match.Home, match.Away} {
+			espnTotal, _ := getScoreAndProjected(team)
+			calculated := calculateTeamPoints(settings, team, week)
+			if math.Abs(espnTotal-calculated) > scoreTolerance {
+				discrepancies = append(discrepancies, models.ScoreDiscrepancy{
+					TeamID:     team.TeamID,
+					TeamName:   getTeamName(team.TeamID),
+					ESPN:       espnTotal,
+					Calculated: calculated,
+				})
+			}
+		}
+	}
+
+	return discrepancies, nil
+}
+
+func calculateTeamPoints(settings models.ScoringSettings, team models.TeamScore, week int) float64 {
+	var total float64
+	for _, entry := range team.RosterForCurrentScoringPeriod.Entries {
+		if !isStartingLineup(entry.LineupSlotID) {
+			continue
+		}
+		player := entry.PlayerPoolEntry.Player
+		for _, stat := range player.Stats {
+			if stat.StatSourceID == 0 && stat.ScoringPeriodID == week {
+				points, _ := calculatePoints(settings, player, stat)
+				total += points
+				break
+			}
+		}
+	}
+	return math.Round(total*100) / 100
+}
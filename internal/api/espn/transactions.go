@@ -0,0 +1,317 @@
+package espn
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+type TransactionsResponse struct {
+	Transactions []Transaction `json:"transactions"`
+}
+
+type Transaction struct {
+	ID                   string            `json:"id"`
+	Type                 string            `json:"type"`
+	Status               string            `json:"status"`
+	TeamID               int               `json:"teamId"`
+	BidAmount            int               `json:"bidAmount"`
+	ProposedDate         int64             `json:"proposedDate"`
+	ProcessDate          int64             `json:"processDate"`
+	RelatedTransactionID string            `json:"relatedTransactionId"`
+	Items                []TransactionItem `json:"items"`
+}
+
+type TransactionItem struct {
+	PlayerID   int    `json:"playerId"`
+	Type       string `json:"type"`
+	FromTeamID int    `json:"fromTeamId"`
+	ToTeamID   int    `json:"toTeamId"`
+}
+
+type CommunicationResponse struct {
+	Topics []Topic `json:"topics"`
+}
+
+type Topic struct {
+	ID       string         `json:"id"`
+	Date     int64          `json:"date"`
+	Messages []TopicMessage `json:"messages"`
+}
+
+type TopicMessage struct {
+	MessageTypeID int `json:"messageTypeId"`
+	TargetID      int `json:"targetId"`
+	For           int `json:"for"`
+	From          int `json:"from"`
+	To            int `json:"to"`
+}
+
+// Activity feed message types.
+const (
+	activityFreeAgentAdd  = 178
+	activityFreeAgentDrop = 179
+	activityWaiverAdd     = 180
+	activityWaiverDrop    = 181
+	activityDrop          = 239
+	activityTrade         = 244
+)
+
+// GetTransactions returns the executed adds, drops, waiver claims and
+// accepted trades of a scoring period, oldest first.
+func (a *API) GetTransactions(scoringPeriod int) ([]models.Transaction, error) {
+	var response TransactionsResponse
+	endpoint := fmt.Sprintf("/seasons/%s/segments/0/leagues/%s", a.client.Config.Year, a.client.Config.LeagueID)
+	params := map[string]string{
+		"view":            "mTransactions2",
+		"scoringPeriodId": fmt.Sprintf("%d", scoringPeriod),
+	}
+
+	if err := a.client.Get(endpoint, params, nil, &response); err != nil {
+		return nil, fmt.Errorf("fetching transactions: %w", err)
+	}
+
+	byID := make(map[string]Transaction)
+	for _, t := range response.Transactions {
+		byID[t.ID] = t
+	}
+
+	var transactions []models.Transaction
+	var playerIDs []int
+	for _, t := range response.Transactions {
+		transaction, ok := convertTransaction(t, byID)
+		if !ok {
+			continue
+		}
+		for _, item := range transaction.Items {
+			playerIDs = append(playerIDs, item.Player.PlayerID)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := a.fillTransactionPlayers(transactions, playerIDs, scoringPeriod); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].Date.Before(transactions[j].Date)
+	})
+
+	return transactions, nil
+}
+
+// convertTransaction keeps executed roster moves and drops lineup changes,
+// failed claims and trade proposals that were never accepted. Accepted trades
+// carry their items on the original proposal.
+func convertTransaction(t Transaction, byID map[string]Transaction) (models.Transaction, bool) {
+	var kind models.TransactionKind
+	switch t.Type {
+	case "FREEAGENT":
+		kind = models.TransactionFreeAgent
+	case "WAIVER":
+		kind = models.TransactionWaiver
+	case "TRADE_ACCEPT":
+		kind = models.TransactionTrade
+	default:
+		return models.Transaction{}, false
+	}
+
+	if kind != models.TransactionTrade && t.Status != "EXECUTED" {
+		return models.Transaction{}, false
+	}
+
+	items := t.Items
+	if kind == models.TransactionTrade && len(items) == 0 {
+		items = byID[t.RelatedTransactionID].Items
+	}
+
+	date := t.ProcessDate
+	if date == 0 {
+		date = t.ProposedDate
+	}
+
+	transaction := models.Transaction{
+		ID:       t.ID,
+		Kind:     kind,
+		TeamID:   t.TeamID,
+		TeamName: getTeamName(t.TeamID),
+		Date:     time.UnixMilli(date),
+	}
+	if kind == models.TransactionWaiver {
+		transaction.BidAmount = t.BidAmount
+	}
+
+	for _, item := range items {
+		var action models.TransactionAction
+		switch item.Type {
+		case "ADD":
+			action = models.TransactionAdd
+		case "DROP":
+			action = models.TransactionDrop
+		case "TRADE":
+			action = models.TransactionTraded
+		default:
+			continue
+		}
+
+		transaction.Items = append(transaction.Items, models.TransactionItem{
+			Action:   action,
+			Player:   models.PlayerCandidate{PlayerID: item.PlayerID},
+			FromTeam: teamNameOrNone(item.FromTeamID),
+			ToTeam:   teamNameOrNone(item.ToTeamID),
+		})
+	}
+
+	return transaction, len(transaction.Items) > 0
+}
+
+// GetRecentActivity returns the league's most recent roster moves from the
+// activity feed, newest first. Unlike GetTransactions it covers the whole
+// season, but it has no waiver bids.
+func (a *API) GetRecentActivity(limit, week int) ([]models.Transaction, error) {
+	var response CommunicationResponse
+	endpoint := fmt.Sprintf("/seasons/%s/segments/0/leagues/%s/communication/", a.client.Config.Year, a.client.Config.LeagueID)
+	params := map[string]string{
+		"view": "kona_league_communication",
+	}
+
+	filters := map[string]interface{}{
+		"topics": map[string]interface{}{
+			"filterType": map[string]interface{}{
+				"value": []string{"ACTIVITY_TRANSACTIONS"},
+			},
+			"limit": limit,
+			"limitPerMessageSet": map[string]interface{}{
+				"value": 25,
+			},
+			"offset": 0,
+			"sortMessageDate": map[string]interface{}{
+				"sortPriority": 1,
+				"sortAsc":      false,
+			},
+			"sortFor": map[string]interface{}{
+				"sortPriority": 2,
+				"sortAsc":      false,
+			},
+			"filterIncludeMessageTypeIds": map[string]interface{}{
+				"value": []int{activityFreeAgentAdd, activityFreeAgentDrop, activityWaiverAdd, activityWaiverDrop, activityDrop, activityTrade},
+			},
+		},
+	}
+
+	filtersJSON, err := json.Marshal(filters)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling filters: %w", err)
+	}
+
+	headers := map[string]string{
+		"x-fantasy-filter": string(filtersJSON),
+	}
+
+	if err := a.client.Get(endpoint, params, headers, &response); err != nil {
+		return nil, fmt.Errorf("fetching recent activity: %w", err)
+	}
+
+	var transactions []models.Transaction
+	var playerIDs []int
+	for _, topic := range response.Topics {
+		transaction := models.Transaction{
+			ID:   topic.ID,
+			Kind: models.TransactionFreeAgent,
+			Date: time.UnixMilli(topic.Date),
+		}
+
+		for _, msg := range topic.Messages {
+			item := models.TransactionItem{
+				Player: models.PlayerCandidate{PlayerID: msg.TargetID},
+			}
+
+			switch msg.MessageTypeID {
+			case activityFreeAgentAdd, activityWaiverAdd:
+				item.Action = models.TransactionAdd
+				item.ToTeam = teamNameOrNone(msg.To)
+				transaction.TeamID = msg.To
+				if msg.MessageTypeID == activityWaiverAdd {
+					transaction.Kind = models.TransactionWaiver
+				}
+			case activityFreeAgentDrop, activityWaiverDrop:
+				item.Action = models.TransactionDrop
+				item.FromTeam = teamNameOrNone(msg.From)
+				transaction.TeamID = msg.From
+			case activityDrop:
+				item.Action = models.TransactionDrop
+				item.FromTeam = teamNameOrNone(msg.For)
+				transaction.TeamID = msg.For
+			case activityTrade:
+				item.Action = models.TransactionTraded
+				item.FromTeam = teamNameOrNone(msg.From)
+				item.ToTeam = teamNameOrNone(msg.To)
+				transaction.Kind = models.TransactionTrade
+				transaction.TeamID = msg.From
+			default:
+				continue
+			}
+
+			transaction.Items = append(transaction.Items, item)
+			playerIDs = append(playerIDs, msg.TargetID)
+		}
+
+		if len(transaction.Items) == 0 {
+			continue
+		}
+		transaction.TeamName = getTeamName(transaction.TeamID)
+		transactions = append(transactions, transaction)
+	}
+
+	if err := a.fillTransactionPlayers(transactions, playerIDs, week); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// fillTransactionPlayers looks up the name, position and NFL team of every
+// player involved in the transactions.
+func (a *API) fillTransactionPlayers(transactions []models.Transaction, playerIDs []int, week int) error {
+	if len(playerIDs) == 0 {
+		return nil
+	}
+
+	players, err := a.getPlayersByID(playerIDs, week)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int]models.Player, len(players))
+	for _, p := range players {
+		byID[p.Player.ID] = p.Player
+	}
+
+	for i := range transactions {
+		for j := range transactions[i].Items {
+			item := &transactions[i].Items[j]
+			player, ok := byID[item.Player.PlayerID]
+			if !ok {
+				item.Player.Name = fmt.Sprintf("Player %d", item.Player.PlayerID)
+				continue
+			}
+			item.Player.Name = player.FullName
+			item.Player.Position = getPositionString(player.DefaultPositionID)
+			item.Player.ProTeam = getProTeamString(player.ProTeamID)
+		}
+	}
+
+	return nil
+}
+
+// teamNameOrNone returns "" for team ID 0, which ESPN uses for the free
+// agent and waiver pool.
+func teamNameOrNone(teamID int) string {
+	if teamID == 0 {
+		return ""
+	}
+	return getTeamName(teamID)
+}
@@ -1,6 +1,8 @@
 package fantasy
 
 import (
+	"time"
+
 	"github.com/omarshaarawi/coachbot/internal/api/espn"
 	"github.com/omarshaarawi/coachbot/internal/models"
 )
@@ -17,6 +19,10 @@ func (a *API) GetLeagueMetadata() (*models.LeagueMetadata, error) {
 	return a.espnAPI.GetLeagueMetadata()
 }
 
+func (a *API) MatchTeamIDs(teamName string) []int {
+	return a.espnAPI.MatchTeamIDs(teamName)
+}
+
 func (a *API) GetStandings() ([]models.TeamStanding, error) {
 	return a.espnAPI.GetStandings()
 }
@@ -29,10 +35,62 @@ func (a *API) WhoHas(playerName string, week int) (models.WhoHasResult, error) {
 	return a.espnAPI.WhoHas(playerName, week)
 }
 
+func (a *API) WhoHasPlayer(playerID int, week int) (models.WhoHasResult, error) {
+	return a.espnAPI.WhoHasPlayer(playerID, week)
+}
+
+func (a *API) SearchPlayers(playerName string, week int, limit int) ([]models.WhoHasResult, error) {
+	return a.espnAPI.SearchPlayers(playerName, week, limit)
+}
+
 func (a *API) GetPlayersToMonitor(week int) (models.PlayersToMonitorReport, error) {
 	return a.espnAPI.GetPlayersToMonitor(week)
 }
 
-func (a *API) GetTeamRoster(teamName string, week int) (models.TeamRoster, error) {
-	return a.espnAPI.GetTeamRoster(teamName, week)
+func (a *API) GetTeamRoster(teamName string, week int, settings models.ScoringSettings) (models.TeamRoster, error) {
+	return a.espnAPI.GetTeamRoster(teamName, week, settings)
+}
+
+func (a *API) GetTeamRosterByID(teamID int, week int, settings models.ScoringSettings) (models.TeamRoster, error) {
+	return a.espnAPI.GetTeamRosterByID(teamID, week, settings)
+}
+
+func (a *API) GetLiveScoreboard(week, scoringPeriod int, now time.Time) (models.LiveScoreboard, error) {
+	return a.espnAPI.GetLiveScoreboard(week, scoringPeriod, now)
+}
+
+func (a *API) GetRosterStatuses(scoringPeriod int, now time.Time) ([]models.PlayerStatus, error) {
+	return a.espnAPI.GetRosterStatuses(scoringPeriod, now)
+}
+
+func (a *API) GetTransactions(scoringPeriod int) ([]models.Transaction, error) {
+	return a.espnAPI.GetTransactions(scoringPeriod)
+}
+
+func (a *API) GetRecentActivity(limit, week int) ([]models.Transaction, error) {
+	return a.espnAPI.GetRecentActivity(limit, week)
+}
+
+func (a *API) GetFreeAgentReport(position string, week, limit int) (models.FreeAgentReport, error) {
+	return a.espnAPI.GetFreeAgentReport(position, week, limit)
+}
+
+func (a *API) GetPlayerStates(playerIDs []int, week int) ([]models.PlayerState, error) {
+	return a.espnAPI.GetPlayerStates(playerIDs, week)
+}
+
+func (a *API) GetPlayerGameLog(playerID, week int, settings models.ScoringSettings) (models.PlayerGameLog, error) {
+	return a.espnAPI.GetPlayerGameLog(playerID, week, settings)
+}
+
+func (a *API) ValidateScores(week int, settings models.ScoringSettings) ([]models.ScoreDiscrepancy, error) {
+	return a.espnAPI.ValidateScores(week, settings)
+}
+
+func (a *API) GetPlayoffBracket(weeks []int) (models.PlayoffBracketReport, error) {
+	return a.espnAPI.GetPlayoffBracket(weeks)
+}
+
+func (a *API) GetProGames(scoringPeriod int) ([]models.ProGame, error) {
+	return a.espnAPI.GetProGames(scoringPeriod)
 }
@@ -1,131 +1,629 @@
 package bot
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
 	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/omarshaarawi/coachbot/internal/metrics"
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/render"
 	"github.com/omarshaarawi/coachbot/internal/service"
 )
 
 type Handler struct {
 	fantasyService *service.FantasyService
+	renderer       render.Renderer
+	admins         map[int64]bool
+	jobs           Jobs
+	// editing maps admins to the approval whose report their next message
+	// replaces.
+	editing map[int64]int
 }
 
-func NewHandler(fantasyService *service.FantasyService) *Handler {
-	return &Handler{fantasyService: fantasyService}
+// Jobs manages the scheduled report jobs and their posts.
+type Jobs interface {
+	Jobs() []models.JobStatus
+	Pause(name string) error
+	Resume(name string) error
+	Run(name string) error
+	Reload() error
+	Retract(report string, week int) (int, error)
+	Preview(id int) (models.Report, error)
+	Approve(id int, adminID int64, by string) error
+	Skip(id int, adminID int64, by string) error
+	EditPreview(id int, adminID int64, by string, text string) error
 }
 
-func (h *Handler) HandleCommand(update tgbotapi.Update) tgbotapi.MessageConfig {
-	msg := tgbotapi.NewMessage(update.Message.Chat.ID, "")
+// NewHandler creates a handler. Only users in adminIDs can use admin
+// commands like /jobs.
+func NewHandler(fantasyService *service.FantasyService, renderer render.Renderer, adminIDs []int64) *Handler {
+	admins := make(map[int64]bool, len(adminIDs))
+	for _, id := range adminIDs {
+		admins[id] = true
+	}
+	return &Handler{fantasyService: fantasyService, renderer: renderer, admins: admins, editing: make(map[int64]int)}
+}
+
+// Reply is what the bot sends back for an update: a report and an optional
+// inline keyboard.
+type Reply struct {
+	Report models.Report
+	Markup *tgbotapi.InlineKeyboardMarkup
+	// Page makes the reply a paginated view with prev/next buttons.
+	Page *Page
+}
+
+// Page identifies one page of a paginated command. Navigating re-runs Command
+// with Arg and renders page Number (zero-based) of the fresh report.
+type Page struct {
+	Command string
+	Arg     int
+	Number  int
+}
+
+func (p Page) callbackData(number int) string {
+	return fmt.Sprintf("page:%s:%d:%d", p.Command, p.Arg, number)
+}
+
+func text(s string) models.Report {
+	return models.Message{Text: s}
+}
+
+func (h *Handler) HandleCommand(update tgbotapi.Update) Reply {
+	var reply Reply
 	command := strings.ToLower(update.Message.Command())
 	args := update.Message.CommandArguments()
-	msg.ParseMode = "Markdown"
 
 	switch command {
 	case "start":
-		msg.Text = "Welcome to CoachBot! Use /help to see available commands."
+		reply.Report = text("Welcome to CoachBot! Use /help to see available commands.")
 	case "help":
-		msg.Text = "Available commands:\n/scores - Get current scores\n/standings - Get league standings\n/team <team> - View team's roster and points\n/whohas <player> - Check which team has a player\n/monitor - Get players to monitor\n/finalscore - Get final score report\n/mondaynight - Get close games for Monday night\n/matchup - Get matchups for this week"
+		reply.Report = text("Available commands:\n/scores - Get current scores\n/standings - Get league standings\n/team <team> - View team's roster and points\n/whohas <player> - Check which team has a player\n/player <player> - Weekly points and this week's stat line\n/monitor - Get players to monitor\n/finalscore - Get final score report\n/mondaynight - Get close games for Monday night\n/matchup - Get matchups for this week\n/transactions [team] - Recent adds, drops and trades\n/fa [position] - Best available free agents\n/watch <player> - Get a private message when a player is dropped or their injury status changes\n/unwatch <player> - Stop watching a player\n/watchlist - Show your watched players\n/alerts on|off - Live lead change and comeback alerts for this chat")
 	case "scores":
-		h.handleScores(&msg)
+		h.handleScores(&reply)
 	case "standings":
-		h.handleStandings(&msg)
+		h.handleStandings(&reply)
 	case "whohas":
-		h.handleWhoHas(&msg, args)
+		h.handleWhoHas(&reply, args)
 	case "monitor":
-		h.handlePlayersToMonitor(&msg)
+		h.handlePlayersToMonitor(&reply)
 	case "finalscore":
-		h.handleFinalScore(&msg)
+		h.handleFinalScore(&reply)
 	case "mondaynight":
-		h.handleMondayNightGames(&msg)
+		h.handleMondayNightGames(&reply)
 	case "matchup":
-		h.handleMatchup(&msg)
+		h.handleMatchup(&reply)
 	case "team":
-		h.handleTeam(&msg, args)
+		h.handleTeam(&reply, args)
+	case "transactions":
+		h.handleTransactions(&reply, args)
+	case "player":
+		h.handlePlayer(&reply, args)
+	case "fa":
+		h.handleFreeAgents(&reply, strings.TrimSpace(args))
+	case "watch":
+		h.handleWatch(&reply, update.Message.From.ID, args)
+	case "unwatch":
+		h.handleUnwatch(&reply, update.Message.From.ID, args)
+	case "watchlist":
+		reply.Report = h.fantasyService.GetWatchlist(update.Message.From.ID)
+	case "alerts":
+		h.handleAlerts(&reply, update.Message.From.ID, update.Message.Chat.ID, args)
+	case "jobs":
+		h.handleJobs(&reply, update.Message.From.ID, args)
+	case "retract":
+		h.handleRetract(&reply, update.Message.From.ID, args)
+	case "cancel":
+		h.handleCancel(&reply, update.Message.From.ID)
 	default:
-		msg.Text = "Unknown command. Use /help to see available commands."
+		reply.Report = text("Unknown command. Use /help to see available commands.")
+		command = "unknown"
 	}
 
-	return msg
+	metrics.Commands.WithLabelValues(command).Inc()
+	return reply
 }
 
-func (h *Handler) handleScores(msg *tgbotapi.MessageConfig) {
+func (h *Handler) handleScores(reply *Reply) {
 	scores, err := h.fantasyService.GetCurrentScores()
 	if err != nil {
-		msg.Text = fmt.Sprintf("Error fetching scores: %v", err)
+		reply.Report = text(fmt.Sprintf("Error fetching scores: %v", err))
 	} else {
-		msg.Text = scores
+		reply.Report = scores
 	}
 }
 
-func (h *Handler) handleStandings(msg *tgbotapi.MessageConfig) {
+func (h *Handler) handleStandings(reply *Reply) {
 	standings, err := h.fantasyService.GetStandings()
 	if err != nil {
-		msg.Text = fmt.Sprintf("Error fetching standings: %v", err)
+		reply.Report = text(fmt.Sprintf("Error fetching standings: %v", err))
 	} else {
-		msg.Text = standings
+		reply.Report = standings
+		reply.Page = &Page{Command: "standings"}
 	}
 }
 
-func (h *Handler) handleWhoHas(msg *tgbotapi.MessageConfig, args string) {
+func (h *Handler) handleWhoHas(reply *Reply, args string) {
 	if args == "" {
-		msg.Text = "Please provide a player name. Usage: /whohas <player name>"
+		reply.Report = text("Please provide a player name. Usage: /whohas <player name>")
 		return
 	}
+	var ambiguous *service.AmbiguousMatchError
 	result, err := h.fantasyService.WhoHas(args)
-	if err != nil {
-		msg.Text = fmt.Sprintf("Error checking who has player: %v", err)
+	if errors.As(err, &ambiguous) {
+		setChoices(reply, "whohas", fmt.Sprintf("Which player did you mean by '%s'?", args), ambiguous.Choices)
+	} else if err != nil {
+		reply.Report = text(fmt.Sprintf("Error checking who has player: %v", err))
 	} else {
-		msg.Text = result
+		reply.Report = result
 	}
 }
 
-func (h *Handler) handlePlayersToMonitor(msg *tgbotapi.MessageConfig) {
+func (h *Handler) handlePlayersToMonitor(reply *Reply) {
 	report, err := h.fantasyService.GetPlayersToMonitor()
 	if err != nil {
-		msg.Text = fmt.Sprintf("Error fetching players to monitor: %v", err)
+		reply.Report = text(fmt.Sprintf("Error fetching players to monitor: %v", err))
 	} else {
-		msg.Text = report
+		reply.Report = report
+		reply.Page = &Page{Command: "monitor"}
 	}
 }
 
-func (h *Handler) handleFinalScore(msg *tgbotapi.MessageConfig) {
+func (h *Handler) handleFinalScore(reply *Reply) {
 	report, err := h.fantasyService.GetFinalScoreReport()
 	if err != nil {
-		msg.Text = fmt.Sprintf("Error generating final score report: %v", err)
+		reply.Report = text(fmt.Sprintf("Error generating final score report: %v", err))
 	} else {
-		msg.Text = report
+		reply.Report = report
 	}
 }
 
-func (h *Handler) handleMondayNightGames(msg *tgbotapi.MessageConfig) {
+func (h *Handler) handleMondayNightGames(reply *Reply) {
 	report, err := h.fantasyService.GetMondayNightCloseGames()
 	if err != nil {
-		msg.Text = fmt.Sprintf("Error generating Monday night close games report: %v", err)
+		reply.Report = text(fmt.Sprintf("Error generating Monday night close games report: %v", err))
 	} else {
-		msg.Text = report
+		reply.Report = report
 	}
 }
 
-func (h *Handler) handleMatchup(msg *tgbotapi.MessageConfig) {
+func (h *Handler) handleMatchup(reply *Reply) {
 	report, err := h.fantasyService.GetMatchups()
 	if err != nil {
-		msg.Text = fmt.Sprintf("Error generating matchups report: %v", err)
+		reply.Report = text(fmt.Sprintf("Error generating matchups report: %v", err))
 	} else {
-		msg.Text = report
+		reply.Report = report
 	}
 }
 
-func (h *Handler) handleTeam(msg *tgbotapi.MessageConfig, args string) {
+func (h *Handler) handleTeam(reply *Reply, args string) {
 	if args == "" {
-		msg.Text = "Please provide a team name. Usage: /team <team name>"
+		reply.Report = text("Please provide a team name. Usage: /team <team name>")
 		return
 	}
+	var ambiguous *service.AmbiguousMatchError
 	result, err := h.fantasyService.GetTeamRoster(args)
+	if errors.As(err, &ambiguous) {
+		setChoices(reply, "team", fmt.Sprintf("Which team did you mean by '%s'?", args), ambiguous.Choices)
+	} else if err != nil {
+		reply.Report = text(fmt.Sprintf("Error getting team roster: %v", err))
+	} else {
+		reply.Report = result
+		reply.Page = &Page{Command: "team", Arg: result.TeamID}
+	}
+}
+
+func (h *Handler) handleTransactions(reply *Reply, args string) {
+	var ambiguous *service.AmbiguousMatchError
+	result, err := h.fantasyService.GetTransactionHistory(strings.TrimSpace(args))
+	if errors.As(err, &ambiguous) {
+		setChoices(reply, "transactions", fmt.Sprintf("Which team did you mean by '%s'?", args), ambiguous.Choices)
+	} else if err != nil {
+		reply.Report = text(fmt.Sprintf("Error getting transactions: %v", err))
+	} else {
+		reply.Report = result
+		reply.Page = &Page{Command: "transactions", Arg: result.TeamID}
+	}
+}
+
+func (h *Handler) handlePlayer(reply *Reply, args string) {
+	if args == "" {
+		reply.Report = text("Please provide a player name. Usage: /player <player name>")
+		return
+	}
+	var ambiguous *service.AmbiguousMatchError
+	result, err := h.fantasyService.GetPlayerGameLog(args)
+	if errors.As(err, &ambiguous) {
+		setChoices(reply, "player", fmt.Sprintf("Which player did you mean by '%s'?", args), ambiguous.Choices)
+	} else if err != nil {
+		reply.Report = text(fmt.Sprintf("Error getting player: %v", err))
+	} else {
+		reply.Report = result
+	}
+}
+
+func (h *Handler) handleFreeAgents(reply *Reply, position string) {
+	result, err := h.fantasyService.GetFreeAgents(position)
 	if err != nil {
-		msg.Text = fmt.Sprintf("Error getting team roster: %v", err)
+		reply.Report = text(fmt.Sprintf("Error getting free agents: %v", err))
+		return
+	}
+	reply.Report = result
+	// Page arguments are numbers: 0 for all positions, otherwise the
+	// position's index in FreeAgentPositions plus one.
+	reply.Page = &Page{Command: "fa", Arg: slices.Index(service.FreeAgentPositions, result.Position) + 1}
+}
+
+func (h *Handler) handleWatch(reply *Reply, userID int64, args string) {
+	if args == "" {
+		reply.Report = text("Please provide a player name. Usage: /watch <player name>")
+		return
+	}
+	var ambiguous *service.AmbiguousMatchError
+	player, err := h.fantasyService.WatchPlayer(userID, args)
+	if errors.As(err, &ambiguous) {
+		setChoices(reply, "watch", fmt.Sprintf("Which player did you mean by '%s'?", args), ambiguous.Choices)
+	} else if err != nil {
+		reply.Report = text(fmt.Sprintf("Error watching player: %v", err))
 	} else {
-		msg.Text = result
+		reply.Report = watchingText(player)
+	}
+}
+
+func watchingText(player models.WatchedPlayer) models.Report {
+	return text(fmt.Sprintf("👀 Watching %s. I'll message you privately if they're dropped or their injury status changes, so make sure you've started a chat with me.", player.Name))
+}
+
+func (h *Handler) handleUnwatch(reply *Reply, userID int64, args string) {
+	if args == "" {
+		reply.Report = text("Please provide a player name. Usage: /unwatch <player name>")
+		return
+	}
+	var ambiguous *service.AmbiguousMatchError
+	player, err := h.fantasyService.UnwatchPlayer(userID, args)
+	if errors.As(err, &ambiguous) {
+		setChoices(reply, "unwatch", fmt.Sprintf("Which player did you mean by '%s'?", args), ambiguous.Choices)
+	} else if err != nil {
+		reply.Report = text(fmt.Sprintf("Error unwatching player: %v", err))
+	} else {
+		reply.Report = text(fmt.Sprintf("Stopped watching %s.", player.Name))
+	}
+}
+
+func (h *Handler) handleJobs(reply *Reply, userID int64, args string) {
+	if !h.admins[userID] {
+		reply.Report = text("Only league admins can manage jobs.")
+		return
+	}
+	if h.jobs == nil {
+		reply.Report = text("The scheduler isn't running.")
+		return
+	}
+
+	action, name, _ := strings.Cut(strings.TrimSpace(args), " ")
+	name = strings.TrimSpace(name)
+
+	var err error
+	switch strings.ToLower(action) {
+	case "", "list":
+		phase, err := h.fantasyService.GetSeasonPhase()
+		if err != nil {
+			slog.Error("Failed to get season phase", "error", err)
+		}
+		reply.Report = models.JobsReport{Phase: phase, Jobs: h.jobs.Jobs()}
+		return
+	case "reload":
+		err = h.jobs.Reload()
+		reply.Report = text("🔄 Schedule reloaded.")
+	case "pause":
+		err = h.jobs.Pause(name)
+		reply.Report = text(fmt.Sprintf("⏸ Paused %s.", name))
+	case "resume":
+		err = h.jobs.Resume(name)
+		reply.Report = text(fmt.Sprintf("▶️ Resumed %s.", name))
+	case "run":
+		err = h.jobs.Run(name)
+		reply.Report = text(fmt.Sprintf("✅ Ran %s.", name))
+	default:
+		reply.Report = text("Usage: /jobs list|reload|pause <job>|resume <job>|run <job>")
+		return
+	}
+
+	if err != nil {
+		reply.Report = text(fmt.Sprintf("Error: %v", err))
+	}
+}
+
+func (h *Handler) handleRetract(reply *Reply, userID int64, args string) {
+	if !h.admins[userID] {
+		reply.Report = text("Only league admins can retract posts.")
+		return
+	}
+	if h.jobs == nil {
+		reply.Report = text("The scheduler isn't running.")
+		return
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) == 0 || len(fields) > 2 {
+		reply.Report = text("Usage: /retract <report> [week]")
+		return
+	}
+
+	week := 0
+	if len(fields) == 2 {
+		var err error
+		if week, err = strconv.Atoi(fields[1]); err != nil || week < 1 {
+			reply.Report = text("Usage: /retract <report> [week]")
+			return
+		}
+	}
+
+	deleted, err := h.jobs.Retract(fields[0], week)
+	if err != nil {
+		reply.Report = text(fmt.Sprintf("Error: %v", err))
+		return
 	}
+	reply.Report = text(fmt.Sprintf("🗑 Deleted %d message(s) of %s.", deleted, fields[0]))
+}
+
+// approvalKeyboard has the buttons under a scheduled report's preview.
+func approvalKeyboard(id int) *tgbotapi.InlineKeyboardMarkup {
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("✅ Approve", fmt.Sprintf("approve:%d", id)),
+		tgbotapi.NewInlineKeyboardButtonData("✏️ Edit", fmt.Sprintf("edit:%d", id)),
+		tgbotapi.NewInlineKeyboardButtonData("⏭ Skip", fmt.Sprintf("skip:%d", id)),
+	))
+	return &markup
+}
+
+// handleApproval acts on a button under a scheduled report's preview.
+func (h *Handler) handleApproval(reply *Reply, from *tgbotapi.User, action string, id int) {
+	if !h.admins[from.ID] {
+		reply.Report = text("Only league admins can approve reports.")
+		return
+	}
+	if h.jobs == nil {
+		reply.Report = text("The scheduler isn't running.")
+		return
+	}
+
+	by := adminName(from)
+	var err error
+	switch action {
+	case "approve":
+		if err = h.jobs.Approve(id, from.ID, by); err == nil {
+			delete(h.editing, from.ID)
+			reply.Report = text("✅ Approved, posting it now.")
+		}
+	case "skip":
+		if err = h.jobs.Skip(id, from.ID, by); err == nil {
+			delete(h.editing, from.ID)
+			reply.Report = text("⏭ Skipped.")
+		}
+	case "edit":
+		var preview models.Report
+		if preview, err = h.jobs.Preview(id); err == nil {
+			h.editing[from.ID] = id
+			reply.Report = text(render.Plain.Render(preview) + "\n\n✏️ Send the corrected report as your next message. It's posted as plain text in place of the one above. /cancel to keep it as it was.")
+			reply.Markup = approvalKeyboard(id)
+		}
+	}
+	if err != nil {
+		reply.Report = text(fmt.Sprintf("Error: %v", err))
+	}
+}
+
+// HandleText handles a message that isn't a command. The only ones the bot
+// answers are corrected reports from admins editing a preview.
+func (h *Handler) HandleText(update tgbotapi.Update) (Reply, bool) {
+	var reply Reply
+
+	userID := update.Message.From.ID
+	id, ok := h.editing[userID]
+	if !ok || h.jobs == nil {
+		return reply, false
+	}
+	delete(h.editing, userID)
+
+	if err := h.jobs.EditPreview(id, userID, adminName(update.Message.From), update.Message.Text); err != nil {
+		reply.Report = text(fmt.Sprintf("Error: %v", err))
+		return reply, true
+	}
+	reply.Report = text("✏️ Report updated. The admins get a new preview to approve.")
+	return reply, true
+}
+
+func (h *Handler) handleCancel(reply *Reply, userID int64) {
+	if _, ok := h.editing[userID]; !ok {
+		reply.Report = text("There's nothing to cancel.")
+		return
+	}
+	delete(h.editing, userID)
+	reply.Report = text("The report was left as it was.")
+}
+
+// adminName is how an admin is named to the other admins.
+func adminName(user *tgbotapi.User) string {
+	if user.UserName != "" {
+		return "@" + user.UserName
+	}
+	return user.FirstName
+}
+
+func (h *Handler) handleAlerts(reply *Reply, userID, chatID int64, args string) {
+	if !h.admins[userID] {
+		reply.Report = text("Only league admins can change live alerts.")
+		return
+	}
+
+	switch strings.ToLower(strings.TrimSpace(args)) {
+	case "on":
+		h.fantasyService.SetLiveAlerts(chatID, true)
+		reply.Report = text("🔔 Live game alerts are on for this chat.")
+	case "off":
+		h.fantasyService.SetLiveAlerts(chatID, false)
+		reply.Report = text("🔕 Live game alerts are off for this chat.")
+	default:
+		reply.Report = text("Usage: /alerts on|off")
+	}
+}
+
+// setChoices turns reply into a prompt with one inline button per choice. The
+// button's callback data is "<action>:<id>" and is resolved by HandleCallback.
+func setChoices(reply *Reply, action, prompt string, choices []service.Choice) {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, choice := range choices {
+		data := fmt.Sprintf("%s:%d", action, choice.ID)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(choice.Label, data)))
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	reply.Report = text(prompt)
+	reply.Markup = &markup
+}
+
+// HandleCallback resolves an inline keyboard press. The returned reply
+// replaces the message that carried the keyboard. Callback data is either
+// "<action>:<id>" from a choice prompt or "page:<command>:<arg>:<page>".
+func (h *Handler) HandleCallback(query *tgbotapi.CallbackQuery) Reply {
+	var reply Reply
+
+	fields := strings.Split(query.Data, ":")
+	if fields[0] == "page" && len(fields) == 4 {
+		arg, argErr := strconv.Atoi(fields[2])
+		number, numberErr := strconv.Atoi(fields[3])
+		if argErr != nil || numberErr != nil {
+			reply.Report = text("Sorry, that page is no longer available.")
+			return reply
+		}
+		h.handlePage(&reply, Page{Command: fields[1], Arg: arg, Number: number})
+		return reply
+	}
+
+	if len(fields) != 2 {
+		reply.Report = text("Sorry, that selection is no longer valid.")
+		return reply
+	}
+
+	id, err := strconv.Atoi(fields[1])
+	if err != nil {
+		reply.Report = text("Sorry, that selection is no longer valid.")
+		return reply
+	}
+
+	switch fields[0] {
+	case "whohas":
+		result, err := h.fantasyService.WhoHasPlayer(id)
+		if err != nil {
+			reply.Report = text(fmt.Sprintf("Error checking who has player: %v", err))
+		} else {
+			reply.Report = result
+		}
+	case "team", "transactions":
+		h.handlePage(&reply, Page{Command: fields[0], Arg: id})
+	case "player":
+		result, err := h.fantasyService.GetPlayerGameLogByID(id)
+		if err != nil {
+			reply.Report = text(fmt.Sprintf("Error getting player: %v", err))
+		} else {
+			reply.Report = result
+		}
+	case "watch":
+		player, err := h.fantasyService.WatchPlayerByID(query.From.ID, id)
+		if err != nil {
+			reply.Report = text(fmt.Sprintf("Error watching player: %v", err))
+		} else {
+			reply.Report = watchingText(player)
+		}
+	case "unwatch":
+		player := h.fantasyService.UnwatchPlayerByID(query.From.ID, id)
+		reply.Report = text(fmt.Sprintf("Stopped watching %s.", player.Name))
+	case "approve", "edit", "skip":
+		h.handleApproval(&reply, query.From, fields[0], id)
+	default:
+		reply.Report = text("Sorry, that selection is no longer valid.")
+	}
+
+	return reply
+}
+
+func (h *Handler) handlePage(reply *Reply, page Page) {
+	switch page.Command {
+	case "standings":
+		h.handleStandings(reply)
+	case "monitor":
+		h.handlePlayersToMonitor(reply)
+	case "team":
+		result, err := h.fantasyService.GetTeamRosterByID(page.Arg)
+		if err != nil {
+			reply.Report = text(fmt.Sprintf("Error getting team roster: %v", err))
+			return
+		}
+		reply.Report = result
+		reply.Page = &page
+	case "fa":
+		position := ""
+		if page.Arg > 0 && page.Arg <= len(service.FreeAgentPositions) {
+			position = service.FreeAgentPositions[page.Arg-1]
+		}
+		h.handleFreeAgents(reply, position)
+	case "transactions":
+		result, err := h.fantasyService.GetTransactionHistoryByTeamID(page.Arg)
+		if err != nil {
+			reply.Report = text(fmt.Sprintf("Error getting transactions: %v", err))
+			return
+		}
+		reply.Report = result
+		reply.Page = &page
+	default:
+		reply.Report = text("Sorry, that page is no longer available.")
+		return
+	}
+
+	if reply.Page != nil {
+		reply.Page.Number = page.Number
+	}
+}
+
+// minInlineQueryLength avoids searching the whole player pool for a letter or two.
+const minInlineQueryLength = 3
+
+// HandleInlineQuery answers "@coachbot <player>" from any chat with a result
+// card per matching player.
+func (h *Handler) HandleInlineQuery(query *tgbotapi.InlineQuery) tgbotapi.InlineConfig {
+	inline := tgbotapi.InlineConfig{
+		InlineQueryID: query.ID,
+		CacheTime:     60,
+		Results:       []interface{}{},
+	}
+
+	search := strings.TrimSpace(query.Query)
+	if len(search) < minInlineQueryLength {
+		return inline
+	}
+
+	cards, err := h.fantasyService.SearchPlayerCards(search)
+	if err != nil {
+		slog.Error("Error searching players for inline query", "error", err)
+		inline.CacheTime = 0
+		return inline
+	}
+
+	for _, card := range cards {
+		article := tgbotapi.NewInlineQueryResultArticle(strconv.Itoa(card.PlayerID), card.Title, "")
+		article.InputMessageContent = tgbotapi.InputTextMessageContent{
+			Text:      h.renderer.Render(card.Result),
+			ParseMode: h.renderer.ParseMode(),
+		}
+		article.Description = card.Description
+		inline.Results = append(inline.Results, article)
+	}
+
+	return inline
 }
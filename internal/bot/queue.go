@@ -0,0 +1,382 @@
+package bot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/omarshaarawi/coachbot/internal/metrics"
+)
+
+// Priority orders queued messages: live alerts and replies to members go
+// before scheduled digests.
+type Priority int
+
+const (
+	PriorityNormal Priority = iota
+	PriorityHigh
+)
+
+// Telegram allows about 20 messages a minute in a group, one a second in a
+// private chat and 30 a second overall.
+const (
+	groupInterval   = 3 * time.Second
+	privateInterval = time.Second
+	globalInterval  = time.Second / 30
+)
+
+// maxSendAttempts is how often a message is tried when the network or
+// Telegram fails. Flood control waits don't count.
+const maxSendAttempts = 5
+
+type requestKind string
+
+const (
+	requestSend   requestKind = "send"
+	requestEdit   requestKind = "edit"
+	requestDelete requestKind = "delete"
+)
+
+// request is a queued call to Telegram. PlainText is sent instead of Text
+// if Telegram can't parse Text's formatting. Redone requests are part of a
+// job run that catch-up redoes after a restart, so they aren't restored.
+type request struct {
+	Seq       int64                          `json:"seq"`
+	Kind      requestKind                    `json:"kind"`
+	ChatID    int64                          `json:"chat_id"`
+	MessageID int                            `json:"message_id,omitempty"`
+	Text      string                         `json:"text,omitempty"`
+	ParseMode string                         `json:"parse_mode,omitempty"`
+	PlainText string                         `json:"plain_text,omitempty"`
+	Markup    *tgbotapi.InlineKeyboardMarkup `json:"markup,omitempty"`
+	Priority  Priority                       `json:"priority"`
+	Attempts  int                            `json:"attempts"`
+	NotBefore time.Time                      `json:"not_before"`
+	Redone    bool                           `json:"redone,omitempty"`
+
+	done chan result
+}
+
+type result struct {
+	msg tgbotapi.Message
+	err error
+}
+
+func (r *request) chattable(plain bool) tgbotapi.Chattable {
+	text, parseMode := r.Text, r.ParseMode
+	if plain {
+		text, parseMode = r.PlainText, ""
+	}
+
+	switch r.Kind {
+	case requestEdit:
+		edit := tgbotapi.NewEditMessageText(r.ChatID, r.MessageID, text)
+		edit.ParseMode = parseMode
+		edit.ReplyMarkup = r.Markup
+		return edit
+	case requestDelete:
+		return tgbotapi.NewDeleteMessage(r.ChatID, r.MessageID)
+	default:
+		msg := tgbotapi.NewMessage(r.ChatID, text)
+		msg.ParseMode = parseMode
+		if r.Markup != nil {
+			msg.ReplyMarkup = *r.Markup
+		}
+		return msg
+	}
+}
+
+// sender is the part of the Telegram API the queue calls.
+type sender interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+	Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
+}
+
+// queue sends requests to Telegram one at a time, spacing them out per chat
+// so flood limits aren't hit, and retries them when Telegram asks to wait or
+// the network fails. Pending requests are saved to disk so they survive a
+// restart.
+type queue struct {
+	bot sender
+	now func() time.Time
+
+	mu       sync.Mutex
+	pending  []*request
+	seq      int64
+	nextChat map[int64]time.Time
+	nextAny  time.Time
+	path     string
+	wake     chan struct{}
+}
+
+func newQueue(bot sender) *queue {
+	q := &queue{
+		bot:      bot,
+		now:      time.Now,
+		nextChat: make(map[int64]time.Time),
+		wake:     make(chan struct{}, 1),
+	}
+	go q.run()
+	return q
+}
+
+// persist saves pending requests at path from now on.
+func (q *queue) persist(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating data directory: %w", err)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.path = path
+	return nil
+}
+
+// restore queues the requests saved by a previous run, except those catch-up
+// sends again. Nobody waits for their results.
+func (q *queue) restore() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(q.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading send queue: %w", err)
+	}
+
+	var saved []*request
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("parsing send queue %s: %w", q.path, err)
+	}
+
+	dropped := 0
+	for _, r := range saved {
+		if r.Redone {
+			dropped++
+			continue
+		}
+		q.seq++
+		r.Seq = q.seq
+		q.pending = append(q.pending, r)
+	}
+	if len(saved) > 0 {
+		slog.Info("Restored queued messages", "count", len(saved)-dropped, "dropped", dropped)
+		q.save()
+		q.signal()
+	}
+	return nil
+}
+
+// save writes the pending requests to disk. Callers must hold the lock.
+func (q *queue) save() {
+	if q.path == "" {
+		return
+	}
+
+	data, err := json.Marshal(q.pending)
+	if err != nil {
+		slog.Error("Failed to encode send queue", "error", err)
+		return
+	}
+	tmp := q.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		slog.Error("Failed to save send queue", "path", q.path, "error", err)
+		return
+	}
+	if err := os.Rename(tmp, q.path); err != nil {
+		slog.Error("Failed to save send queue", "path", q.path, "error", err)
+	}
+}
+
+func (q *queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// do queues requests in order and waits for all of them to be sent.
+func (q *queue) do(requests ...*request) []result {
+	q.add(requests...)
+	return wait(requests)
+}
+
+// add queues requests in order without waiting for them; wait collects
+// their results.
+func (q *queue) add(requests ...*request) {
+	q.mu.Lock()
+	for _, r := range requests {
+		q.seq++
+		r.Seq = q.seq
+		r.done = make(chan result, 1)
+		q.pending = append(q.pending, r)
+	}
+	q.save()
+	q.mu.Unlock()
+	q.signal()
+}
+
+// wait returns the results of requests queued with add, once all of them
+// are sent.
+func wait(requests []*request) []result {
+	results := make([]result, len(requests))
+	for i, r := range requests {
+		results[i] = <-r.done
+	}
+	return results
+}
+
+func (q *queue) run() {
+	for {
+		r, wait := q.next()
+		if r == nil {
+			timer := time.NewTimer(wait)
+			select {
+			case <-q.wake:
+			case <-timer.C:
+			}
+			timer.Stop()
+			continue
+		}
+		q.deliver(r)
+	}
+}
+
+// next returns the request to send now, or how long to wait for one. Of the
+// requests that are due, the one with the highest priority goes first, and
+// requests of the same priority to the same chat keep their order.
+func (q *queue) next() (*request, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	wait := time.Hour
+	var best *request
+
+	type head struct {
+		chatID   int64
+		priority Priority
+	}
+	seen := make(map[head]bool)
+	for _, r := range q.pending {
+		h := head{r.ChatID, r.Priority}
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+
+		due := maxTime(r.NotBefore, q.nextChat[r.ChatID], q.nextAny)
+		if due.After(now) {
+			wait = min(wait, due.Sub(now))
+			continue
+		}
+		if best == nil || r.Priority > best.Priority {
+			best = r
+		}
+	}
+	return best, wait
+}
+
+func maxTime(times ...time.Time) time.Time {
+	var latest time.Time
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// deliver makes one attempt at a request and either finishes it or leaves
+// it queued for a retry.
+func (q *queue) deliver(r *request) {
+	msg, err := q.call(r.chattable(false), r.Kind)
+	if err != nil && r.ParseMode != "" && isParseError(err) {
+		slog.Warn("Telegram rejected message formatting, retrying as plain text", "error", err)
+		msg, err = q.call(r.chattable(true), r.Kind)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	interval := privateInterval
+	if r.ChatID < 0 {
+		interval = groupInterval
+	}
+	q.nextChat[r.ChatID] = now.Add(interval)
+	q.nextAny = now.Add(globalInterval)
+
+	var apiErr *tgbotapi.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.RetryAfter > 0:
+		metrics.TelegramRequests.WithLabelValues(string(r.Kind), "rate_limited").Inc()
+		wait := time.Duration(apiErr.RetryAfter) * time.Second
+		slog.Warn("Telegram flood control, waiting", "chat", r.ChatID, "retry_after", wait)
+		r.NotBefore = now.Add(wait)
+		q.nextChat[r.ChatID] = r.NotBefore
+		q.save()
+		return
+	case err != nil && retryable(err) && r.Attempts+1 < maxSendAttempts:
+		metrics.TelegramRequests.WithLabelValues(string(r.Kind), "retried").Inc()
+		r.Attempts++
+		backoff := time.Second << r.Attempts
+		slog.Warn("Sending to Telegram failed, retrying", "chat", r.ChatID, "attempt", r.Attempts, "backoff", backoff, "error", err)
+		r.NotBefore = now.Add(backoff)
+		q.save()
+		return
+	}
+
+	for i, pending := range q.pending {
+		if pending == r {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			break
+		}
+	}
+	q.save()
+
+	if err != nil && !isNotModified(err) {
+		metrics.TelegramRequests.WithLabelValues(string(r.Kind), "failed").Inc()
+	} else {
+		metrics.TelegramRequests.WithLabelValues(string(r.Kind), "sent").Inc()
+	}
+
+	if r.done != nil {
+		r.done <- result{msg: msg, err: err}
+	} else if err != nil && !isNotModified(err) {
+		slog.Error("Failed to send queued message", "chat", r.ChatID, "error", err)
+	}
+}
+
+// call sends c. Deleting a message returns no message, so it's sent as a
+// plain request.
+func (q *queue) call(c tgbotapi.Chattable, kind requestKind) (tgbotapi.Message, error) {
+	if kind == requestDelete {
+		_, err := q.bot.Request(c)
+		return tgbotapi.Message{}, err
+	}
+	return q.bot.Send(c)
+}
+
+// retryable reports errors that may go away on their own: network failures
+// and Telegram server errors, as opposed to requests Telegram refused.
+func retryable(err error) bool {
+	var apiErr *tgbotapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code >= 500
+	}
+	return true
+}
@@ -0,0 +1,295 @@
+package bot
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// fakeSender records what the queue sends and fails with the queued errors
+// first.
+type fakeSender struct {
+	sent []string
+	errs []error
+}
+
+func (f *fakeSender) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		if err != nil {
+			return tgbotapi.Message{}, err
+		}
+	}
+	msg := c.(tgbotapi.MessageConfig)
+	f.sent = append(f.sent, msg.Text)
+	return tgbotapi.Message{MessageID: len(f.sent)}, nil
+}
+
+func (f *fakeSender) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	return &tgbotapi.APIResponse{Ok: true}, nil
+}
+
+// testQueue is a queue that doesn't run on its own: the test steps it and
+// moves its clock.
+type testQueue struct {
+	*queue
+	sender *fakeSender
+	clock  time.Time
+}
+
+func newTestQueue() *testQueue {
+	tq := &testQueue{
+		sender: &fakeSender{},
+		clock:  time.Date(2025, time.September, 14, 12, 0, 0, 0, time.UTC),
+	}
+	tq.queue = &queue{
+		bot:      tq.sender,
+		now:      func() time.Time { return tq.clock },
+		nextChat: make(map[int64]time.Time),
+		wake:     make(chan struct{}, 1),
+	}
+	return tq
+}
+
+// step delivers the next request that is due, moving the clock ahead to it
+// if needed. It reports false once nothing is pending.
+func (tq *testQueue) step() bool {
+	for len(tq.pending) > 0 {
+		r, wait := tq.next()
+		if r != nil {
+			tq.deliver(r)
+			return true
+		}
+		tq.clock = tq.clock.Add(wait)
+	}
+	return false
+}
+
+func (tq *testQueue) drain() {
+	for tq.step() {
+	}
+}
+
+func message(chatID int64, text string, priority Priority) *request {
+	return &request{Kind: requestSend, ChatID: chatID, Text: text, Priority: priority}
+}
+
+func TestQueueOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests []*request
+		want     []string
+	}{
+		{
+			name: "same chat keeps its order",
+			requests: []*request{
+				message(1, "a1", PriorityNormal),
+				message(1, "a2", PriorityNormal),
+				message(1, "a3", PriorityNormal),
+			},
+			want: []string{"a1", "a2", "a3"},
+		},
+		{
+			name: "high priority goes first",
+			requests: []*request{
+				message(1, "digest", PriorityNormal),
+				message(2, "alert", PriorityHigh),
+			},
+			want: []string{"alert", "digest"},
+		},
+		{
+			name: "high priority overtakes in the same chat",
+			requests: []*request{
+				message(1, "digest 1", PriorityNormal),
+				message(1, "digest 2", PriorityNormal),
+				message(1, "alert", PriorityHigh),
+			},
+			want: []string{"alert", "digest 1", "digest 2"},
+		},
+		{
+			name: "a waiting chat doesn't hold up others",
+			requests: []*request{
+				message(-1, "group 1", PriorityNormal),
+				message(-1, "group 2", PriorityNormal),
+				message(2, "private", PriorityNormal),
+			},
+			want: []string{"group 1", "private", "group 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueue()
+			q.add(tt.requests...)
+			q.drain()
+			if !reflect.DeepEqual(q.sender.sent, tt.want) {
+				t.Errorf("sent %q, want %q", q.sender.sent, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueueSpacing(t *testing.T) {
+	q := newTestQueue()
+	start := q.clock
+	q.add(message(-1, "group 1", PriorityNormal), message(-1, "group 2", PriorityNormal))
+	q.add(message(1, "private 1", PriorityNormal), message(1, "private 2", PriorityNormal))
+
+	var at []time.Duration
+	for q.step() {
+		at = append(at, q.clock.Sub(start))
+	}
+
+	want := []time.Duration{0, globalInterval, globalInterval + privateInterval, groupInterval}
+	if !reflect.DeepEqual(at, want) {
+		t.Errorf("sent at %v, want %v", at, want)
+	}
+	if order := []string{"group 1", "private 1", "private 2", "group 2"}; !reflect.DeepEqual(q.sender.sent, order) {
+		t.Errorf("sent %q, want %q", q.sender.sent, order)
+	}
+}
+
+func TestQueueRetry(t *testing.T) {
+	floodWait := &tgbotapi.Error{Code: 429, Message: "Too Many Requests", ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 7}}
+	network := errors.New("connection reset")
+	refused := &tgbotapi.Error{Code: 400, Message: "Bad Request: chat not found"}
+
+	tests := []struct {
+		name     string
+		errs     []error
+		wantWait time.Duration
+		attempts int
+		wantErr  error
+	}{
+		{
+			name:     "flood control waits retry_after without using an attempt",
+			errs:     []error{floodWait, floodWait},
+			wantWait: 14 * time.Second,
+		},
+		{
+			name:     "network errors back off",
+			errs:     []error{network, network},
+			wantWait: 2*time.Second + 4*time.Second,
+			attempts: 2,
+		},
+		{
+			name:     "gives up after the last attempt",
+			errs:     []error{network, network, network, network, network},
+			wantWait: 2*time.Second + 4*time.Second + 8*time.Second + 16*time.Second,
+			attempts: maxSendAttempts - 1,
+			wantErr:  network,
+		},
+		{
+			name:    "refused requests aren't retried",
+			errs:    []error{refused},
+			wantErr: refused,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueue()
+			q.sender.errs = tt.errs
+			start := q.clock
+
+			r := message(1, "hello", PriorityHigh)
+			q.add(r)
+			q.drain()
+
+			if waited := q.clock.Sub(start); waited != tt.wantWait {
+				t.Errorf("waited %v, want %v", waited, tt.wantWait)
+			}
+			if r.Attempts != tt.attempts {
+				t.Errorf("attempts = %d, want %d", r.Attempts, tt.attempts)
+			}
+			res := wait([]*request{r})[0]
+			if !errors.Is(res.err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", res.err, tt.wantErr)
+			}
+			if tt.wantErr == nil && res.msg.MessageID != 1 {
+				t.Errorf("message ID = %d, want 1", res.msg.MessageID)
+			}
+		})
+	}
+}
+
+func TestQueueFloodControlHoldsChat(t *testing.T) {
+	q := newTestQueue()
+	q.sender.errs = []error{&tgbotapi.Error{Code: 429, ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 30}}}
+	q.add(message(1, "first", PriorityNormal), message(1, "second", PriorityNormal), message(2, "other", PriorityNormal))
+
+	if !q.step() {
+		t.Fatal("nothing was delivered")
+	}
+	if len(q.sender.sent) != 0 {
+		t.Fatalf("sent %q before the flood wait", q.sender.sent)
+	}
+	if q.pending[0].NotBefore != q.clock.Add(30*time.Second) {
+		t.Errorf("NotBefore = %v, want 30s from now", q.pending[0].NotBefore)
+	}
+
+	q.drain()
+	if want := []string{"other", "first", "second"}; !reflect.DeepEqual(q.sender.sent, want) {
+		t.Errorf("sent %q, want %q", q.sender.sent, want)
+	}
+}
+
+func TestQueueRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+	saved := []*request{
+		{Seq: 7, Kind: requestSend, ChatID: 1, Text: "reply", Attempts: 2},
+		{Seq: 8, Kind: requestSend, ChatID: -1, Text: "scheduled digest", Redone: true},
+		{Seq: 9, Kind: requestEdit, ChatID: -1, MessageID: 42, Text: "live board"},
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	q := newTestQueue()
+	if err := q.persist(path); err != nil {
+		t.Fatalf("persist: %v", err)
+	}
+	if err := q.restore(); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+
+	var texts []string
+	var seqs []int64
+	for _, r := range q.pending {
+		texts = append(texts, r.Text)
+		seqs = append(seqs, r.Seq)
+	}
+	if want := []string{"reply", "live board"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("restored %q, want %q", texts, want)
+	}
+	if want := []int64{1, 2}; !reflect.DeepEqual(seqs, want) {
+		t.Errorf("seqs = %v, want %v", seqs, want)
+	}
+	if q.pending[0].Attempts != 2 {
+		t.Errorf("attempts = %d, want 2 kept from before the restart", q.pending[0].Attempts)
+	}
+
+	// The file is rewritten without the dropped request.
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rewritten []*request
+	if err := json.Unmarshal(data, &rewritten); err != nil {
+		t.Fatal(err)
+	}
+	if len(rewritten) != 2 {
+		t.Errorf("saved %d requests, want 2", len(rewritten))
+	}
+}
@@ -1,74 +1,469 @@
 package bot
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/omarshaarawi/coachbot/internal/config"
+	"github.com/omarshaarawi/coachbot/internal/health"
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/render"
 	"github.com/omarshaarawi/coachbot/internal/service"
 )
 
 type TelegramBot struct {
-	bot     *tgbotapi.BotAPI
-	handler *Handler
-	chatID  int64
+	bot      *tgbotapi.BotAPI
+	handler  *Handler
+	renderer render.Renderer
+	queue    *queue
+	chatID   int64
+	webhook  *webhook
+
+	loop      atomic.Int32
+	heartbeat atomic.Int64
 }
 
-func NewTelegramBot(token string, chatID int64, fantasyService *service.FantasyService) (*TelegramBot, error) {
-	bot, err := tgbotapi.NewBotAPI(token)
+// States of the update loop, for Health.
+const (
+	loopWaiting int32 = iota
+	loopRunning
+	loopStopped
+)
+
+// The update loop beats every heartbeatInterval while it isn't busy with an
+// update; one that hasn't for maxHeartbeatAge is stuck.
+const (
+	heartbeatInterval = 15 * time.Second
+	maxHeartbeatAge   = 2 * time.Minute
+)
+
+func NewTelegramBot(cfg config.TelegramBot, fantasyService *service.FantasyService) (*TelegramBot, error) {
+	renderer, err := render.New(render.Format(strings.ToLower(cfg.Format)))
+	if err != nil {
+		return nil, err
+	}
+
+	bot, err := tgbotapi.NewBotAPI(cfg.Token)
 	if err != nil {
 		return nil, err
 	}
 
-	handler := NewHandler(fantasyService)
+	handler := NewHandler(fantasyService, renderer, cfg.AdminIDs)
+
+	t := &TelegramBot{
+		bot:      bot,
+		handler:  handler,
+		renderer: renderer,
+		queue:    newQueue(bot),
+		chatID:   cfg.ChatID,
+	}
+
+	if cfg.WebhookURL != "" {
+		t.webhook, err = newWebhook(bot, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// SetJobs lets admins manage the scheduled jobs with /jobs.
+func (t *TelegramBot) SetJobs(jobs Jobs) {
+	t.handler.jobs = jobs
+}
 
-	return &TelegramBot{
-		bot:     bot,
-		handler: handler,
-		chatID:  chatID,
-	}, nil
+// PersistQueue saves messages waiting to be sent at path, so they're sent
+// after a restart.
+func (t *TelegramBot) PersistQueue(path string) error {
+	return t.queue.persist(path)
 }
 
 func (t *TelegramBot) Start(ctx context.Context) error {
 	slog.Info("Authorized on account", "username", t.bot.Self.UserName)
-	u := tgbotapi.NewUpdate(0)
-	u.Timeout = 60
 
-	updates := t.bot.GetUpdatesChan(u)
+	// Messages queued by the previous leader are sent once this instance
+	// takes over.
+	if err := t.queue.restore(); err != nil {
+		slog.Error("Failed to restore send queue", "error", err)
+	}
+
+	defer t.loop.Store(loopStopped)
+
+	updates, err := t.startReceiving()
+	if err != nil {
+		return err
+	}
+	defer t.stopReceiving()
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
 
+	t.beat()
+	t.loop.Store(loopRunning)
 	for {
 		select {
 		case update := <-updates:
-			if update.Message == nil {
-				continue
-			}
-
-			slog.Info("Chat ID", "chatID", t.chatID)
-
-			if update.Message.IsCommand() {
-				msg := t.handler.HandleCommand(update)
-				if _, err := t.bot.Send(msg); err != nil {
-					slog.Error("Error sending message", "error", err)
-				}
-			}
+			t.handleUpdate(update)
+			t.beat()
+		case <-ticker.C:
+			t.beat()
 		case <-ctx.Done():
 			return nil
 		}
 	}
 }
 
-func (t *TelegramBot) SendMessage(text string) error {
-	if t.chatID == 0 {
-		slog.Error("Chat ID not set")
-		return fmt.Errorf("chat ID not set")
+func (t *TelegramBot) beat() {
+	t.heartbeat.Store(time.Now().UnixNano())
+}
+
+// Health reports whether the update loop is running and not stuck. Before
+// Start, the instance is a standby waiting to become the leader.
+func (t *TelegramBot) Health(context.Context) health.Result {
+	switch t.loop.Load() {
+	case loopWaiting:
+		return health.Standby("waiting to become the leader")
+	case loopStopped:
+		return health.Failing("update loop stopped")
 	}
 
-	msg := tgbotapi.NewMessage(t.chatID, text)
-	msg.ParseMode = "Markdown"
-	_, err := t.bot.Send(msg)
-	if err != nil {
-		slog.Error("Error sending message", "error", err)
+	since := time.Since(time.Unix(0, t.heartbeat.Load())).Round(time.Second)
+	if since > maxHeartbeatAge {
+		return health.Failing(fmt.Sprintf("update loop stuck, last heartbeat %s ago", since))
+	}
+	return health.OK(fmt.Sprintf("last heartbeat %s ago", since))
+}
+
+// startReceiving registers the webhook or, in polling mode, clears any
+// webhook left behind by a previous deploy so getUpdates is allowed.
+func (t *TelegramBot) startReceiving() (tgbotapi.UpdatesChannel, error) {
+	if t.webhook != nil {
+		if err := t.webhook.register(); err != nil {
+			return nil, err
+		}
+		slog.Info("Receiving updates via webhook", "url", t.webhook.url.String())
+		return t.webhook.updates, nil
+	}
+
+	if _, err := t.bot.Request(tgbotapi.DeleteWebhookConfig{}); err != nil {
+		return nil, fmt.Errorf("deleting webhook: %w", err)
+	}
+
+	u := tgbotapi.NewUpdate(0)
+	u.Timeout = 60
+
+	slog.Info("Receiving updates via long polling")
+	return t.bot.GetUpdatesChan(u), nil
+}
+
+func (t *TelegramBot) stopReceiving() {
+	if t.webhook != nil {
+		t.webhook.unregister()
+		return
+	}
+	t.bot.StopReceivingUpdates()
+}
+
+func (t *TelegramBot) handleUpdate(update tgbotapi.Update) {
+	if update.CallbackQuery != nil {
+		t.handleCallback(update.CallbackQuery)
+		return
+	}
+
+	if update.InlineQuery != nil {
+		inline := t.handler.HandleInlineQuery(update.InlineQuery)
+		if _, err := t.bot.Request(inline); err != nil {
+			slog.Error("Error answering inline query", "error", err)
+		}
+		return
+	}
+
+	if update.Message == nil {
+		return
+	}
+
+	slog.Info("Chat ID", "chatID", t.chatID)
+
+	var reply Reply
+	switch {
+	case update.Message.IsCommand():
+		reply = t.handler.HandleCommand(update)
+	case update.Message.Chat.IsPrivate() && update.Message.Text != "":
+		var ok bool
+		if reply, ok = t.handler.HandleText(update); !ok {
+			return
+		}
+	default:
+		return
+	}
+
+	// The reply is queued in order but delivered in the background, so a
+	// chat under flood control doesn't hold up the update loop.
+	requests := t.sendRequests(update.Message.Chat.ID, reply, PriorityHigh)
+	t.queue.add(requests...)
+	go func() {
+		if _, err := sentIDs(wait(requests)); err != nil {
+			slog.Error("Error sending message", "error", err)
+		}
+	}()
+}
+
+func (t *TelegramBot) handleCallback(query *tgbotapi.CallbackQuery) {
+	// Acknowledge first so the button stops spinning even if the lookup is slow.
+	if _, err := t.bot.Request(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		slog.Error("Error answering callback query", "error", err)
+	}
+
+	if query.Message == nil {
+		return
+	}
+
+	chatID, messageIDs := query.Message.Chat.ID, []int{query.Message.MessageID}
+	requests := t.editRequests(chatID, messageIDs, t.handler.HandleCallback(query), PriorityHigh)
+	t.queue.add(requests...)
+	go func() {
+		if _, err := t.edited(chatID, messageIDs, requests); err != nil {
+			slog.Error("Error editing message", "error", err)
+		}
+	}()
+}
+
+// reportPriority puts live alerts ahead of scheduled digests in the send
+// queue.
+func reportPriority(report models.Report) Priority {
+	switch report.(type) {
+	case models.LiveScoreboard, models.LiveEventsReport, models.MatchupResultReport,
+		models.InjuryReport, models.TransactionsReport, models.WatchEventsReport, models.Message:
+		return PriorityHigh
+	default:
+		return PriorityNormal
+	}
+}
+
+// SendReport sends a report to chatID and returns the ID of the (last)
+// message it was sent as.
+func (t *TelegramBot) SendReport(chatID int64, report models.Report) (int, error) {
+	ids, err := t.send(chatID, Reply{Report: report}, reportPriority(report))
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return ids[len(ids)-1], nil
+}
+
+// PostReport sends a scheduled report to chatID and returns the IDs of all
+// the messages it was split into that were sent, even if others failed. If
+// the bot restarts before they're sent they're dropped, since the run isn't
+// recorded as done and catch-up posts the report again.
+func (t *TelegramBot) PostReport(chatID int64, report models.Report) ([]int, error) {
+	requests := t.sendRequests(chatID, Reply{Report: report}, reportPriority(report))
+	for _, r := range requests {
+		r.Redone = true
 	}
+	return t.sendAll(requests)
+}
+
+// EditReport replaces the content of a message previously sent by the bot.
+func (t *TelegramBot) EditReport(chatID int64, messageID int, report models.Report) error {
+	_, err := t.edit(chatID, []int{messageID}, Reply{Report: report}, reportPriority(report))
 	return err
 }
+
+// ReplaceReport edits messages sent by PostReport to show report instead.
+// Parts that no longer have a message are sent as new ones and messages
+// that are no longer needed are deleted. It returns the IDs the report now
+// takes up.
+func (t *TelegramBot) ReplaceReport(chatID int64, messageIDs []int, report models.Report) ([]int, error) {
+	return t.edit(chatID, messageIDs, Reply{Report: report}, reportPriority(report))
+}
+
+// SendPreview sends a scheduled report to an admin with buttons to approve,
+// edit or skip it.
+func (t *TelegramBot) SendPreview(chatID int64, report models.Report, approvalID int) ([]int, error) {
+	return t.send(chatID, Reply{Report: report, Markup: approvalKeyboard(approvalID)}, PriorityHigh)
+}
+
+// DeleteMessages deletes messages the bot sent.
+func (t *TelegramBot) DeleteMessages(chatID int64, messageIDs []int) error {
+	requests := make([]*request, len(messageIDs))
+	for i, id := range messageIDs {
+		requests[i] = &request{Kind: requestDelete, ChatID: chatID, MessageID: id, Priority: PriorityNormal}
+	}
+
+	var errs []error
+	for i, res := range t.queue.do(requests...) {
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("deleting message %d: %w", messageIDs[i], res.err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// pageLines is how many lines a page of a paginated view holds besides the
+// report heading.
+const pageLines = 40
+
+// parts renders reply into the messages to send. Paginated replies are a
+// single page with navigation buttons; anything else is split to fit
+// Telegram's message limit, with the keyboard on the last message.
+func (t *TelegramBot) parts(reply Reply) ([]render.Part, *tgbotapi.InlineKeyboardMarkup) {
+	if reply.Page == nil {
+		return t.renderer.Split(reply.Report, render.MessageLimit), reply.Markup
+	}
+
+	part, pages := t.renderer.Page(reply.Report, reply.Page.Number, pageLines)
+	return []render.Part{part}, pageKeyboard(*reply.Page, pages)
+}
+
+func pageKeyboard(page Page, pages int) *tgbotapi.InlineKeyboardMarkup {
+	if pages <= 1 {
+		return nil
+	}
+
+	number := max(0, min(page.Number, pages-1))
+
+	var row []tgbotapi.InlineKeyboardButton
+	if number > 0 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("‹ Prev", page.callbackData(number-1)))
+	}
+	row = append(row, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d/%d", number+1, pages), page.callbackData(number)))
+	if number < pages-1 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("Next ›", page.callbackData(number+1)))
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(row)
+	return &markup
+}
+
+// request renders part into a queued request. The plain text version is
+// kept in case Telegram rejects the formatting.
+func (t *TelegramBot) request(kind requestKind, chatID int64, messageID int, part render.Part, markup *tgbotapi.InlineKeyboardMarkup, priority Priority) *request {
+	r := &request{
+		Kind:      kind,
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      t.renderer.Render(part),
+		ParseMode: t.renderer.ParseMode(),
+		Markup:    markup,
+		Priority:  priority,
+	}
+	if r.ParseMode != "" {
+		r.PlainText = render.Plain.Render(part)
+	}
+	return r
+}
+
+// send sends reply and returns the IDs of the messages that were sent, with
+// the keyboard on the last one.
+func (t *TelegramBot) send(chatID int64, reply Reply, priority Priority) ([]int, error) {
+	return t.sendAll(t.sendRequests(chatID, reply, priority))
+}
+
+// sendRequests renders reply into the requests that send it.
+func (t *TelegramBot) sendRequests(chatID int64, reply Reply, priority Priority) []*request {
+	parts, markup := t.parts(reply)
+
+	requests := make([]*request, len(parts))
+	for i, part := range parts {
+		var partMarkup *tgbotapi.InlineKeyboardMarkup
+		if i == len(parts)-1 {
+			partMarkup = markup
+		}
+		requests[i] = t.request(requestSend, chatID, 0, part, partMarkup, priority)
+	}
+	return requests
+}
+
+// sendAll queues requests and returns the IDs of the messages that were
+// sent.
+func (t *TelegramBot) sendAll(requests []*request) ([]int, error) {
+	return sentIDs(t.queue.do(requests...))
+}
+
+// sentIDs returns the IDs of the messages that were sent and the first
+// error.
+func sentIDs(results []result) ([]int, error) {
+	var ids []int
+	var firstErr error
+	for _, res := range results {
+		if res.err != nil {
+			firstErr = cmp.Or(firstErr, res.err)
+			continue
+		}
+		ids = append(ids, res.msg.MessageID)
+	}
+	return ids, firstErr
+}
+
+// edit replaces messages with reply, part by part. If the reply no longer
+// fits in them, the rest is sent as new messages; messages left over are
+// deleted. It returns the IDs the reply now takes up, keeping messages that
+// failed to update so they can be edited again.
+func (t *TelegramBot) edit(chatID int64, messageIDs []int, reply Reply, priority Priority) ([]int, error) {
+	requests := t.editRequests(chatID, messageIDs, reply, priority)
+	t.queue.add(requests...)
+	return t.edited(chatID, messageIDs, requests)
+}
+
+// editRequests renders reply into the requests that edit messageIDs.
+func (t *TelegramBot) editRequests(chatID int64, messageIDs []int, reply Reply, priority Priority) []*request {
+	parts, markup := t.parts(reply)
+
+	requests := make([]*request, len(parts))
+	for i, part := range parts {
+		var partMarkup *tgbotapi.InlineKeyboardMarkup
+		if i == len(parts)-1 {
+			partMarkup = markup
+		}
+		if i < len(messageIDs) {
+			requests[i] = t.request(requestEdit, chatID, messageIDs[i], part, partMarkup, priority)
+		} else {
+			requests[i] = t.request(requestSend, chatID, 0, part, partMarkup, priority)
+		}
+	}
+	return requests
+}
+
+// edited waits for the queued edit requests and deletes the messages the
+// reply no longer needs.
+func (t *TelegramBot) edited(chatID int64, messageIDs []int, requests []*request) ([]int, error) {
+	var ids []int
+	var firstErr error
+	for i, res := range wait(requests) {
+		if res.err != nil && !isNotModified(res.err) {
+			firstErr = cmp.Or(firstErr, res.err)
+		}
+		switch {
+		case i < len(messageIDs):
+			ids = append(ids, messageIDs[i])
+		case res.err == nil:
+			ids = append(ids, res.msg.MessageID)
+		}
+	}
+
+	if len(messageIDs) > len(requests) {
+		if err := t.DeleteMessages(chatID, messageIDs[len(requests):]); err != nil {
+			slog.Warn("Failed to delete leftover messages", "chat", chatID, "error", err)
+		}
+	}
+	return ids, firstErr
+}
+
+func isParseError(err error) bool {
+	var apiErr *tgbotapi.Error
+	return errors.As(err, &apiErr) && strings.Contains(apiErr.Message, "can't parse entities")
+}
+
+// isNotModified reports Telegram refusing an edit that would leave the
+// message unchanged, e.g. when the current page button is pressed.
+func isNotModified(err error) bool {
+	var apiErr *tgbotapi.Error
+	return errors.As(err, &apiErr) && strings.Contains(apiErr.Message, "message is not modified")
+}
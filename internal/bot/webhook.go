@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/omarshaarawi/coachbot/internal/config"
+)
+
+const secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+type webhook struct {
+	bot          *tgbotapi.BotAPI
+	url          *url.URL
+	secret       string
+	deleteOnStop bool
+	updates      chan tgbotapi.Update
+}
+
+func newWebhook(bot *tgbotapi.BotAPI, cfg config.TelegramBot) (*webhook, error) {
+	u, err := url.Parse(cfg.WebhookURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing webhook URL: %w", err)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("webhook URL must use https: %s", cfg.WebhookURL)
+	}
+	if u.Path == "" || u.Path == "/" {
+		return nil, fmt.Errorf("webhook URL must include a path: %s", cfg.WebhookURL)
+	}
+	if cfg.WebhookSecret == "" {
+		return nil, errors.New("webhook needs a secret token")
+	}
+
+	return &webhook{
+		bot:          bot,
+		url:          u,
+		secret:       cfg.WebhookSecret,
+		deleteOnStop: cfg.WebhookDeleteOnStop,
+		updates:      make(chan tgbotapi.Update, 100),
+	}, nil
+}
+
+// register points Telegram at this instance. Pending updates are kept so that
+// anything queued while containers were swapped is delivered to the new one.
+func (w *webhook) register() error {
+	params := tgbotapi.Params{}
+	params["url"] = w.url.String()
+	params["secret_token"] = w.secret
+	params.AddBool("drop_pending_updates", false)
+
+	if _, err := w.bot.MakeRequest("setWebhook", params); err != nil {
+		return fmt.Errorf("setting webhook: %w", err)
+	}
+	return nil
+}
+
+// unregister removes the webhook on shutdown. It is opt-in because during a
+// rolling deploy the old container stops after the new one has registered the
+// same URL, and deleting it then would leave the new container deaf.
+func (w *webhook) unregister() {
+	if !w.deleteOnStop {
+		return
+	}
+	if _, err := w.bot.Request(tgbotapi.DeleteWebhookConfig{}); err != nil {
+		slog.Error("Error deleting webhook", "error", err)
+	}
+}
+
+func (w *webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get(secretTokenHeader)
+	if w.secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(w.secret)) != 1 {
+		http.Error(rw, "invalid secret token", http.StatusUnauthorized)
+		return
+	}
+
+	update, err := w.bot.HandleUpdate(r)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Only acknowledge once the update is queued; a non-2xx response makes
+	// Telegram redeliver it instead of losing it.
+	select {
+	case w.updates <- *update:
+		rw.WriteHeader(http.StatusOK)
+	case <-r.Context().Done():
+		http.Error(rw, "update queue full", http.StatusServiceUnavailable)
+	}
+}
+
+// WebhookHandler returns the HTTP handler for Telegram webhook calls and the
+// path it should be mounted on. ok is false when the bot uses long polling.
+func (t *TelegramBot) WebhookHandler() (path string, handler http.Handler, ok bool) {
+	if t.webhook == nil {
+		return "", nil, false
+	}
+	return t.webhook.url.Path, t.webhook, true
+}
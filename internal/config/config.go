@@ -1,15 +1,41 @@
 package config
 
-import "github.com/kelseyhightower/envconfig"
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
 
 type Config struct {
-	TelegramBot TelegramBot
-	ESPNAPI     ESPNAPI
+	TelegramBot  TelegramBot
+	ESPNAPI      ESPNAPI
+	Live         Live
+	Injuries     Injuries
+	Transactions Transactions
+	Watchlist    Watchlist
+	Scheduler    Scheduler
+	Storage      Storage
 }
 
 type TelegramBot struct {
 	Token  string `envconfig:"TELEGRAM_TOKEN" required:"true"`
 	ChatID int64  `envconfig:"CHAT_ID" required:"true"`
+
+	// Format is how reports are rendered: markdownv2, html, plain or json.
+	Format string `envconfig:"TELEGRAM_FORMAT" default:"markdownv2"`
+
+	// WebhookURL switches the bot from long polling to webhook mode when set.
+	// It must be the public HTTPS address that routes to the HTTP server,
+	// e.g. https://coachbot.example.com/telegram/webhook. WebhookSecret is
+	// then required, since anyone who finds the URL could post updates.
+	WebhookURL          string `envconfig:"TELEGRAM_WEBHOOK_URL"`
+	WebhookSecret       string `envconfig:"TELEGRAM_WEBHOOK_SECRET"`
+	WebhookDeleteOnStop bool   `envconfig:"TELEGRAM_WEBHOOK_DELETE_ON_STOP" default:"false"`
+
+	// AdminIDs are the Telegram user IDs allowed to use admin commands.
+	AdminIDs []int64 `envconfig:"TELEGRAM_ADMIN_IDS"`
 }
 
 type ESPNAPI struct {
@@ -19,11 +45,94 @@ type ESPNAPI struct {
 	ESPNS2   string `envconfig:"ESPN_S2" required:"true"`
 }
 
+// Live configures features that poll scores while NFL games are being played.
+type Live struct {
+	BoardEnabled bool          `envconfig:"LIVE_BOARD_ENABLED" default:"false"`
+	PollInterval time.Duration `envconfig:"LIVE_POLL_INTERVAL" default:"3m"`
+
+	// AlertsEnabled turns on lead change and comeback alerts. The league
+	// chat is subscribed on start; other chats can opt in with /alerts on.
+	AlertsEnabled   bool          `envconfig:"LIVE_ALERTS_ENABLED" default:"false"`
+	AlertThrottle   time.Duration `envconfig:"LIVE_ALERT_THROTTLE" default:"10m"`
+	ComebackPoints  float64       `envconfig:"LIVE_COMEBACK_POINTS" default:"20"`
+	LateLeadPlayers int           `envconfig:"LIVE_LATE_LEAD_PLAYERS" default:"3"`
+
+	// ResultsEnabled posts a notice when a matchup is decided and again when
+	// ESPN makes the result official.
+	ResultsEnabled bool    `envconfig:"LIVE_RESULTS_ENABLED" default:"false"`
+	CatchUpFactor  float64 `envconfig:"LIVE_CATCH_UP_FACTOR" default:"2.5"`
+}
+
+// Injuries configures alerts when a rostered player's injury status changes.
+type Injuries struct {
+	AlertsEnabled bool          `envconfig:"INJURY_ALERTS_ENABLED" default:"false"`
+	PollInterval  time.Duration `envconfig:"INJURY_POLL_INTERVAL" default:"15m"`
+
+	// TeamChats routes a team's alerts to its owner instead of the league
+	// chat, as teamID:chatID pairs, e.g. "2:123456789,3:987654321".
+	TeamChats map[int]int64 `envconfig:"INJURY_TEAM_CHATS"`
+}
+
+// Transactions configures posting roster moves to the league chat.
+type Transactions struct {
+	Enabled      bool          `envconfig:"TRANSACTIONS_ENABLED" default:"false"`
+	PollInterval time.Duration `envconfig:"TRANSACTIONS_POLL_INTERVAL" default:"5m"`
+}
+
+// Watchlist configures how often players on members' watchlists are checked.
+type Watchlist struct {
+	PollInterval time.Duration `envconfig:"WATCHLIST_POLL_INTERVAL" default:"10m"`
+}
+
+// Scheduler configures the scheduled report jobs.
+type Scheduler struct {
+	// File is the JSON schedule. Without one the built-in schedule is used.
+	File           string        `envconfig:"SCHEDULE_FILE" default:"schedule.json"`
+	ReloadInterval time.Duration `envconfig:"SCHEDULE_RELOAD_INTERVAL" default:"1m"`
+
+	// A failed run is retried after RetryBackoff, doubling each time, until
+	// RetryWindow has passed since it was due.
+	RetryWindow  time.Duration `envconfig:"SCHEDULE_RETRY_WINDOW" default:"2h"`
+	RetryBackoff time.Duration `envconfig:"SCHEDULE_RETRY_BACKOFF" default:"1m"`
+
+	// CatchUpWindow is how late a run missed during downtime may still be
+	// made up on start. Jobs can override it.
+	CatchUpWindow time.Duration `envconfig:"SCHEDULE_CATCH_UP_WINDOW" default:"6h"`
+
+	// ApprovalTimeout is how long a job that needs approval waits for an
+	// admin before its report is posted anyway.
+	ApprovalTimeout time.Duration `envconfig:"SCHEDULE_APPROVAL_TIMEOUT" default:"30m"`
+}
+
+// Storage configures where state that has to survive restarts is kept.
+type Storage struct {
+	DataDir string `envconfig:"DATA_DIR" default:"data"`
+}
+
 func New() (*Config, error) {
 	var c Config
 	err := envconfig.Process("", &c)
 	if err != nil {
 		return nil, err
 	}
+	if err := c.TelegramBot.validate(); err != nil {
+		return nil, err
+	}
 	return &c, nil
 }
+
+// webhookSecretPattern is what Telegram accepts as a secret token.
+var webhookSecretPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+func (t TelegramBot) validate() error {
+	if t.WebhookURL == "" {
+		return nil
+	}
+	if t.WebhookSecret == "" {
+		return errors.New("TELEGRAM_WEBHOOK_SECRET is required when TELEGRAM_WEBHOOK_URL is set")
+	}
+	if !webhookSecretPattern.MatchString(t.WebhookSecret) {
+		return errors.New("TELEGRAM_WEBHOOK_SECRET must be 1-256 letters, digits, _ or -")
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Package health serves the liveness and readiness checks on /healthz and
+// /readyz, with the state of each component of the bot as JSON.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusOK Status = "ok"
+	// StatusStandby is a component that is idle because another instance
+	// is the leader. It counts as healthy.
+	StatusStandby Status = "standby"
+	StatusFailing Status = "failing"
+)
+
+// Result is the state of one component.
+type Result struct {
+	Status Status `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func OK(detail string) Result {
+	return Result{Status: StatusOK, Detail: detail}
+}
+
+func Standby(detail string) Result {
+	return Result{Status: StatusStandby, Detail: detail}
+}
+
+func Failing(detail string) Result {
+	return Result{Status: StatusFailing, Detail: detail}
+}
+
+// Check reports on a component. It should return before ctx is done.
+type Check func(ctx context.Context) Result
+
+// checkTimeout bounds a whole round of checks, so a component that hangs
+// shows up as failing instead of hanging the probe.
+const checkTimeout = 2 * time.Second
+
+type component struct {
+	name  string
+	check Check
+	live  bool
+}
+
+// Checker runs the registered checks. Liveness only covers the components
+// whose failure a restart fixes; readiness covers all of them.
+type Checker struct {
+	mu         sync.Mutex
+	components []component
+}
+
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Live registers a check for both liveness and readiness.
+func (c *Checker) Live(name string, check Check) {
+	c.add(component{name: name, check: check, live: true})
+}
+
+// Ready registers a check for readiness only.
+func (c *Checker) Ready(name string, check Check) {
+	c.add(component{name: name, check: check})
+}
+
+func (c *Checker) add(comp component) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.components = append(c.components, comp)
+}
+
+type response struct {
+	Status     Status            `json:"status"`
+	Components map[string]Result `json:"components"`
+}
+
+// Healthz serves liveness: 200 if the live components are healthy, 503 if
+// not.
+func (c *Checker) Healthz() http.Handler {
+	return c.handler(true)
+}
+
+// Readyz serves readiness: 200 if every component is healthy, 503 if not.
+func (c *Checker) Readyz() http.Handler {
+	return c.handler(false)
+}
+
+func (c *Checker) handler(liveOnly bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		defer cancel()
+
+		resp := c.run(ctx, liveOnly)
+		w.Header().Set("Content-Type", "application/json")
+		if resp.Status == StatusFailing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			slog.Error("Failed to write health response", "error", err)
+		}
+	})
+}
+
+// run runs the checks concurrently. A check that doesn't return in time is
+// failing.
+func (c *Checker) run(ctx context.Context, liveOnly bool) response {
+	c.mu.Lock()
+	var components []component
+	for _, comp := range c.components {
+		if comp.live || !liveOnly {
+			components = append(components, comp)
+		}
+	}
+	c.mu.Unlock()
+
+	results := make([]chan Result, len(components))
+	for i, comp := range components {
+		results[i] = make(chan Result, 1)
+		go func() {
+			results[i] <- comp.check(ctx)
+		}()
+	}
+
+	resp := response{Status: StatusOK, Components: make(map[string]Result, len(components))}
+	for i, comp := range components {
+		var result Result
+		select {
+		case result = <-results[i]:
+		case <-ctx.Done():
+			result = Failing("check timed out")
+		}
+		resp.Components[comp.name] = result
+		if result.Status == StatusFailing {
+			resp.Status = StatusFailing
+		}
+	}
+	return resp
+}
@@ -0,0 +1,121 @@
+// Package leader makes sure only one running instance sends scheduled
+// reports and handles Telegram updates, e.g. while a rolling deploy has the
+// old and the new container up at the same time.
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrNotLeader is returned by IsLeader while another instance holds the lock.
+var ErrNotLeader = errors.New("another instance is the leader")
+
+// Elector elects the instance holding an exclusive lock on a file as the
+// leader. The lock is released by the operating system when the process
+// exits, however it exits, so a standby takes over on its next check. It
+// implements gocron.Elector.
+type Elector struct {
+	path string
+
+	mu        sync.Mutex
+	file      *os.File
+	onElected []func()
+}
+
+func NewElector(path string) (*Elector, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating lock directory: %w", err)
+	}
+	return &Elector{path: path}, nil
+}
+
+// OnElected registers f to run when this instance becomes the leader, before
+// IsLeader first returns nil. Hooks run in the order they were registered.
+func (e *Elector) OnElected(f func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onElected = append(e.onElected, f)
+}
+
+// IsLeader returns nil if this instance is the leader, trying to take the
+// lock if it isn't yet.
+func (e *Elector) IsLeader(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.file != nil {
+		return nil
+	}
+
+	file, err := os.OpenFile(e.path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening lock file: %w", err)
+	}
+	if err := tryLock(file); err != nil {
+		file.Close()
+		return ErrNotLeader
+	}
+
+	hostname, _ := os.Hostname()
+	if err := file.Truncate(0); err == nil {
+		fmt.Fprintf(file, "%s %d %s\n", hostname, os.Getpid(), time.Now().Format(time.RFC3339))
+	}
+
+	e.file = file
+	slog.Info("Elected leader", "lock", e.path)
+	for _, f := range e.onElected {
+		f()
+	}
+	return nil
+}
+
+// Leading reports whether this instance is the leader, without trying to
+// take the lock.
+func (e *Elector) Leading() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.file != nil
+}
+
+// Wait blocks until this instance is the leader, checking every interval.
+func (e *Elector) Wait(ctx context.Context, interval time.Duration) error {
+	if e.IsLeader(ctx) == nil {
+		return nil
+	}
+	slog.Info("Waiting to become leader", "lock", e.path)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := e.IsLeader(ctx); err == nil {
+				return nil
+			} else if !errors.Is(err, ErrNotLeader) {
+				slog.Error("Leader election failed", "error", err)
+			}
+		}
+	}
+}
+
+// Resign releases the lock so a standby can take over right away.
+func (e *Elector) Resign() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	return err
+}
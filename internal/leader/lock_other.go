@@ -0,0 +1,11 @@
+//go:build !unix
+
+package leader
+
+import "os"
+
+// tryLock always succeeds where file locks aren't supported, so a single
+// instance still runs everything.
+func tryLock(*os.File) error {
+	return nil
+}
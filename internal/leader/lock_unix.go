@@ -0,0 +1,13 @@
+//go:build unix
+
+package leader
+
+import (
+	"os"
+	"syscall"
+)
+
+// tryLock takes an exclusive lock on file without blocking.
+func tryLock(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+}
@@ -0,0 +1,97 @@
+package live
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// Board keeps one scoreboard message per chat and week. It posts the
+// message when the first starter's game kicks off and edits it whenever the
+// numbers change, through every game window of the week, leaving it showing
+// the final scores in between. A new week gets a new message.
+type Board struct {
+	messenger Messenger
+	chats     []int64
+
+	mu       sync.Mutex
+	week     int
+	messages map[int64]int
+	last     string
+	live     bool
+}
+
+func NewBoard(messenger Messenger, chats []int64) *Board {
+	return &Board{
+		messenger: messenger,
+		chats:     chats,
+		messages:  make(map[int64]int),
+	}
+}
+
+// Update posts or edits the board for a new poll.
+func (b *Board) Update(scoreboard models.LiveScoreboard) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if scoreboard.Week != b.week {
+		b.week = scoreboard.Week
+		b.messages = make(map[int64]int)
+		b.last = ""
+		b.live = false
+	}
+
+	current := fingerprint(scoreboard)
+
+	if len(b.messages) == 0 {
+		if !scoreboard.GamesInProgress {
+			return
+		}
+		slog.Info("Posting live board", "week", scoreboard.Week)
+		for _, chatID := range b.chats {
+			messageID, err := b.messenger.SendReport(chatID, scoreboard)
+			if err != nil {
+				slog.Error("Failed to post live board", "chatID", chatID, "error", err)
+				continue
+			}
+			b.messages[chatID] = messageID
+		}
+		b.last = current
+		b.live = true
+		return
+	}
+
+	if current == b.last && scoreboard.GamesInProgress == b.live {
+		return
+	}
+	switch {
+	case scoreboard.GamesInProgress && !b.live:
+		slog.Info("Reopening live board", "week", scoreboard.Week)
+	case !scoreboard.GamesInProgress && b.live:
+		slog.Info("Closing live board", "week", scoreboard.Week)
+	}
+	for chatID, messageID := range b.messages {
+		if err := b.messenger.EditReport(chatID, messageID, scoreboard); err != nil {
+			slog.Error("Failed to update live board", "chatID", chatID, "error", err)
+		}
+	}
+	b.last = current
+	b.live = scoreboard.GamesInProgress
+}
+
+// fingerprint captures the numbers shown on the board so an edit is only
+// made when one of them changes.
+func fingerprint(scoreboard models.LiveScoreboard) string {
+	var sb strings.Builder
+	for _, m := range scoreboard.Matchups {
+		fmt.Fprintf(&sb, "%d:%.2f/%.2f/%.2f/%.2f/%d/%d/%d/%d;",
+			m.MatchID,
+			m.Home.Score, m.Away.Score, m.Home.Projected, m.Away.Projected,
+			m.Home.PlayersYetToPlay, m.Away.PlayersYetToPlay,
+			m.Home.PlayersInProgress, m.Away.PlayersInProgress)
+	}
+	return sb.String()
+}
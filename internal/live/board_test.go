@@ -0,0 +1,111 @@
+package live
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// fakeMessenger records posts as "send" and edits as "edit <message ID>",
+// and keeps the reports it was sent.
+type fakeMessenger struct {
+	calls   []string
+	reports []models.Report
+	next    int
+}
+
+func (f *fakeMessenger) SendReport(chatID int64, report models.Report) (int, error) {
+	f.next++
+	f.calls = append(f.calls, "send")
+	f.reports = append(f.reports, report)
+	return f.next, nil
+}
+
+func (f *fakeMessenger) EditReport(chatID int64, messageID int, report models.Report) error {
+	f.calls = append(f.calls, fmt.Sprintf("edit %d", messageID))
+	return nil
+}
+
+func TestBoard(t *testing.T) {
+	board := func(week int, score float64, live bool) models.LiveScoreboard {
+		return models.LiveScoreboard{
+			Week:            week,
+			GamesInProgress: live,
+			Matchups:        []models.LiveMatchup{{MatchID: 1, Home: models.LiveTeamScore{Score: score}}},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		polls []models.LiveScoreboard
+		want  []string
+	}{
+		{
+			name:  "nothing before kickoff",
+			polls: []models.LiveScoreboard{board(1, 0, false)},
+		},
+		{
+			name:  "edits only when the numbers change",
+			polls: []models.LiveScoreboard{board(1, 0, true), board(1, 0, true), board(1, 7, true)},
+			want:  []string{"send", "edit 1"},
+		},
+		{
+			name: "same board across the week's game windows",
+			polls: []models.LiveScoreboard{
+				board(1, 7, true),
+				board(1, 7, false),
+				board(1, 7, false),
+				board(1, 7, true),
+				board(1, 20, true),
+			},
+			want: []string{"send", "edit 1", "edit 1", "edit 1"},
+		},
+		{
+			name:  "new board for a new week",
+			polls: []models.LiveScoreboard{board(1, 7, true), board(1, 7, false), board(2, 0, false), board(2, 3, true)},
+			want:  []string{"send", "edit 1", "send"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messenger := &fakeMessenger{}
+			b := NewBoard(messenger, []int64{-100})
+			for _, scoreboard := range tt.polls {
+				b.Update(scoreboard)
+			}
+			if !reflect.DeepEqual(messenger.calls, tt.want) {
+				t.Errorf("calls = %q, want %q", messenger.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestInGameWindow(t *testing.T) {
+	kickoff := time.Date(2025, time.September, 14, 17, 0, 0, 0, time.UTC)
+	games := []models.ProGame{
+		{Kickoff: kickoff},
+		{Kickoff: kickoff.Add(72 * time.Hour), StartTimeTBD: true},
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{name: "hours before kickoff", now: kickoff.Add(-2 * time.Hour), want: false},
+		{name: "just before kickoff", now: kickoff.Add(-windowBefore), want: true},
+		{name: "during the game", now: kickoff.Add(90 * time.Minute), want: true},
+		{name: "overtime", now: kickoff.Add(windowAfter - time.Minute), want: true},
+		{name: "after the window", now: kickoff.Add(windowAfter), want: false},
+		{name: "game without a kickoff time", now: kickoff.Add(72 * time.Hour), want: false},
+	}
+	for _, tt := range tests {
+		if got := inGameWindow(games, tt.now); got != tt.want {
+			t.Errorf("%s: inGameWindow = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package live
+
+import (
+	"cmp"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/omarshaarawi/coachbot/internal/config"
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/repository/memory"
+)
+
+// Detector compares each live poll with the previous one for the same
+// matchup and alerts subscribed chats about lead changes, projection flips
+// and comebacks. Alerts to a chat are throttled; events that arrive in the
+// meantime are batched into the next message.
+type Detector struct {
+	repo            *memory.Repository
+	messenger       Messenger
+	comebackPoints  float64
+	lateLeadPlayers int
+	throttle        time.Duration
+
+	mu       sync.Mutex
+	lastSent map[int64]time.Time
+	pending  map[int64][]models.LiveEvent
+}
+
+func NewDetector(repo *memory.Repository, messenger Messenger, cfg config.Live) *Detector {
+	return &Detector{
+		repo:            repo,
+		messenger:       messenger,
+		comebackPoints:  cfg.ComebackPoints,
+		lateLeadPlayers: cfg.LateLeadPlayers,
+		throttle:        cfg.AlertThrottle,
+		lastSent:        make(map[int64]time.Time),
+		pending:         make(map[int64][]models.LiveEvent),
+	}
+}
+
+// Update detects events in a new poll and sends them to subscribed chats.
+func (d *Detector) Update(scoreboard models.LiveScoreboard) {
+	events := d.detect(scoreboard)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := scoreboard.UpdatedAt
+	for _, chatID := range d.repo.GetAlertChats() {
+		pending := mergeEvents(d.pending[chatID], events)
+		if len(pending) == 0 {
+			continue
+		}
+
+		if now.Sub(d.lastSent[chatID]) < d.throttle {
+			d.pending[chatID] = pending
+			continue
+		}
+
+		if _, err := d.messenger.SendReport(chatID, models.LiveEventsReport{Events: pending}); err != nil {
+			slog.Error("Failed to send live alerts", "chatID", chatID, "error", err)
+			d.pending[chatID] = pending
+			continue
+		}
+
+		d.lastSent[chatID] = now
+		delete(d.pending, chatID)
+	}
+}
+
+func (d *Detector) detect(scoreboard models.LiveScoreboard) []models.LiveEvent {
+	var events []models.LiveEvent
+
+	for _, m := range scoreboard.Matchups {
+		prev, seen := d.repo.GetMatchupSnapshot(m.MatchID)
+		leader := leaderOf(m.Home.Score, m.Away.Score)
+		d.repo.SaveMatchupSnapshot(models.MatchupSnapshot{
+			MatchID:        m.MatchID,
+			HomeScore:      m.Home.Score,
+			AwayScore:      m.Away.Score,
+			HomeProjected:  m.Home.Projected,
+			AwayProjected:  m.Away.Projected,
+			HomeMaxDeficit: max(prev.HomeMaxDeficit, m.Away.Score-m.Home.Score),
+			AwayMaxDeficit: max(prev.AwayMaxDeficit, m.Home.Score-m.Away.Score),
+			Leader:         cmp.Or(leader, prev.Leader),
+		})
+
+		// Scores can still move outside game windows (stat corrections),
+		// which is not worth a live alert.
+		if !seen || !scoreboard.GamesInProgress {
+			continue
+		}
+
+		playersLeft := m.Home.PlayersYetToPlay + m.Home.PlayersInProgress +
+			m.Away.PlayersYetToPlay + m.Away.PlayersInProgress
+
+		// Only a different team taking the lead counts: not the first score
+		// from 0-0, and not the same team going back ahead after a tie.
+		if leader != 0 && prev.Leader != 0 && leader != prev.Leader {
+			event := newEvent(m, leader)
+			event.PlayersLeft = playersLeft
+			event.Deficit = prev.HomeMaxDeficit
+			if leader < 0 {
+				event.Deficit = prev.AwayMaxDeficit
+			}
+
+			switch {
+			case event.Deficit > d.comebackPoints:
+				event.Kind = models.EventComeback
+			case playersLeft <= d.lateLeadPlayers:
+				event.Kind = models.EventLateLead
+			default:
+				event.Kind = models.EventLeadChange
+			}
+			events = append(events, event)
+		}
+
+		prevProjected := leaderOf(prev.HomeProjected, prev.AwayProjected)
+		if leader := leaderOf(m.Home.Projected, m.Away.Projected); leader != 0 && prevProjected != 0 && leader != prevProjected {
+			event := newEvent(m, leader)
+			event.Kind = models.EventProjectionFlip
+			event.PlayersLeft = playersLeft
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// leaderOf returns 1 if home leads, -1 if away leads and 0 for a tie.
+func leaderOf(home, away float64) int {
+	switch {
+	case home > away:
+		return 1
+	case away > home:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func newEvent(m models.LiveMatchup, side int) models.LiveEvent {
+	team, opponent := m.Home, m.Away
+	if side < 0 {
+		team, opponent = m.Away, m.Home
+	}
+
+	return models.LiveEvent{
+		MatchID:           m.MatchID,
+		Team:              team.TeamName,
+		Opponent:          opponent.TeamName,
+		TeamScore:         team.Score,
+		OpponentScore:     opponent.Score,
+		TeamProjected:     team.Projected,
+		OpponentProjected: opponent.Projected,
+	}
+}
+
+// mergeEvents adds events to pending, replacing older events of the same
+// kind for the same matchup since only the latest state is worth telling.
+func mergeEvents(pending, events []models.LiveEvent) []models.LiveEvent {
+	type key struct {
+		matchID int
+		lead    bool
+	}
+	keyOf := func(e models.LiveEvent) key {
+		return key{matchID: e.MatchID, lead: e.Kind != models.EventProjectionFlip}
+	}
+
+	latest := make(map[key]int)
+	merged := append(append([]models.LiveEvent{}, pending...), events...)
+	for i, e := range merged {
+		latest[keyOf(e)] = i
+	}
+
+	var result []models.LiveEvent
+	for i, e := range merged {
+		if latest[keyOf(e)] == i {
+			result = append(result, e)
+		}
+	}
+	return result
+}
@@ -0,0 +1,193 @@
+package live
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/omarshaarawi/coachbot/internal/config"
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/repository/memory"
+)
+
+// poll is one live poll of a single matchup and the events it should raise.
+type poll struct {
+	home, away         float64
+	homeProj, awayProj float64
+	playersLeft        int
+	inProgress         bool
+	want               []models.LiveEventKind
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name  string
+		polls []poll
+	}{
+		{
+			name: "first score of the matchup",
+			polls: []poll{
+				{playersLeft: 18, inProgress: true},
+				{home: 7, playersLeft: 18, inProgress: true},
+			},
+		},
+		{
+			name: "other team takes the lead",
+			polls: []poll{
+				{home: 7, playersLeft: 18, inProgress: true},
+				{home: 7, away: 10, playersLeft: 18, inProgress: true, want: []models.LiveEventKind{models.EventLeadChange}},
+			},
+		},
+		{
+			name: "same team leads again after a tie",
+			polls: []poll{
+				{home: 7, playersLeft: 18, inProgress: true},
+				{home: 7, away: 7, playersLeft: 18, inProgress: true},
+				{home: 10, away: 7, playersLeft: 18, inProgress: true},
+			},
+		},
+		{
+			name: "other team leads after a tie",
+			polls: []poll{
+				{home: 7, playersLeft: 18, inProgress: true},
+				{home: 7, away: 7, playersLeft: 18, inProgress: true},
+				{home: 7, away: 10, playersLeft: 18, inProgress: true, want: []models.LiveEventKind{models.EventLeadChange}},
+			},
+		},
+		{
+			name: "first score after an earlier tie at zero",
+			polls: []poll{
+				{playersLeft: 18, inProgress: true},
+				{home: 3, away: 3, playersLeft: 18, inProgress: true},
+				{away: 6, home: 3, playersLeft: 18, inProgress: true},
+			},
+		},
+		{
+			name: "lead change with few players left",
+			polls: []poll{
+				{home: 90, away: 85, playersLeft: 2, inProgress: true},
+				{home: 90, away: 92, playersLeft: 2, inProgress: true, want: []models.LiveEventKind{models.EventLateLead}},
+			},
+		},
+		{
+			name: "comeback from more than the threshold",
+			polls: []poll{
+				{home: 10, away: 35, playersLeft: 10, inProgress: true},
+				{home: 40, away: 35, playersLeft: 10, inProgress: true, want: []models.LiveEventKind{models.EventComeback}},
+			},
+		},
+		{
+			name: "deficit of exactly the threshold is not a comeback",
+			polls: []poll{
+				{home: 10, away: 30, playersLeft: 10, inProgress: true},
+				{home: 31, away: 30, playersLeft: 10, inProgress: true, want: []models.LiveEventKind{models.EventLeadChange}},
+			},
+		},
+		{
+			name: "projection flip",
+			polls: []poll{
+				{home: 10, homeProj: 100, awayProj: 90, playersLeft: 18, inProgress: true},
+				{home: 12, homeProj: 95, awayProj: 97, playersLeft: 18, inProgress: true, want: []models.LiveEventKind{models.EventProjectionFlip}},
+			},
+		},
+		{
+			name: "no alerts outside game windows",
+			polls: []poll{
+				{home: 7, playersLeft: 18, inProgress: true},
+				{home: 7, away: 10},
+			},
+		},
+		{
+			name: "leader is still tracked outside game windows",
+			polls: []poll{
+				{home: 7, playersLeft: 18, inProgress: true},
+				{home: 7, away: 10},
+				{home: 7, away: 12, playersLeft: 18, inProgress: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDetector(memory.NewRepository(), nil, config.Live{ComebackPoints: 20, LateLeadPlayers: 3})
+			for i, p := range tt.polls {
+				scoreboard := models.LiveScoreboard{
+					GamesInProgress: p.inProgress,
+					Matchups: []models.LiveMatchup{{
+						MatchID: 1,
+						Home:    models.LiveTeamScore{TeamName: "Home", Score: p.home, Projected: p.homeProj, PlayersYetToPlay: p.playersLeft},
+						Away:    models.LiveTeamScore{TeamName: "Away", Score: p.away, Projected: p.awayProj},
+					}},
+				}
+
+				var kinds []models.LiveEventKind
+				for _, e := range d.detect(scoreboard) {
+					kinds = append(kinds, e.Kind)
+				}
+				if !reflect.DeepEqual(kinds, p.want) {
+					t.Errorf("poll %d: events = %v, want %v", i, kinds, p.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeEvents(t *testing.T) {
+	lead := func(matchID int, team string) models.LiveEvent {
+		return models.LiveEvent{Kind: models.EventLeadChange, MatchID: matchID, Team: team}
+	}
+	late := func(matchID int, team string) models.LiveEvent {
+		return models.LiveEvent{Kind: models.EventLateLead, MatchID: matchID, Team: team}
+	}
+	flip := func(matchID int, team string) models.LiveEvent {
+		return models.LiveEvent{Kind: models.EventProjectionFlip, MatchID: matchID, Team: team}
+	}
+
+	tests := []struct {
+		name    string
+		pending []models.LiveEvent
+		events  []models.LiveEvent
+		want    []models.LiveEvent
+	}{
+		{
+			name: "nothing",
+		},
+		{
+			name:   "new events only",
+			events: []models.LiveEvent{lead(1, "A"), flip(1, "A")},
+			want:   []models.LiveEvent{lead(1, "A"), flip(1, "A")},
+		},
+		{
+			name:    "later lead change replaces the pending one",
+			pending: []models.LiveEvent{lead(1, "A")},
+			events:  []models.LiveEvent{lead(1, "B")},
+			want:    []models.LiveEvent{lead(1, "B")},
+		},
+		{
+			name:    "late lead replaces a pending lead change",
+			pending: []models.LiveEvent{lead(1, "A")},
+			events:  []models.LiveEvent{late(1, "B")},
+			want:    []models.LiveEvent{late(1, "B")},
+		},
+		{
+			name:    "lead and projection events are kept apart",
+			pending: []models.LiveEvent{flip(1, "A")},
+			events:  []models.LiveEvent{lead(1, "B")},
+			want:    []models.LiveEvent{flip(1, "A"), lead(1, "B")},
+		},
+		{
+			name:    "other matchups are kept",
+			pending: []models.LiveEvent{lead(1, "A"), lead(2, "C")},
+			events:  []models.LiveEvent{lead(1, "B")},
+			want:    []models.LiveEvent{lead(2, "C"), lead(1, "B")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeEvents(tt.pending, tt.events)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeEvents = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package live
+
+import (
+	"log/slog"
+	"sort"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/service"
+)
+
+// Injuries polls rostered players' injury statuses and alerts when one
+// changes. A team's alerts go to its owner's chat when one is configured and
+// to the league chat otherwise.
+type Injuries struct {
+	fantasyService *service.FantasyService
+	messenger      Messenger
+	groupChat      int64
+	teamChats      map[int]int64
+}
+
+func NewInjuries(fantasyService *service.FantasyService, messenger Messenger, groupChat int64, teamChats map[int]int64) *Injuries {
+	return &Injuries{
+		fantasyService: fantasyService,
+		messenger:      messenger,
+		groupChat:      groupChat,
+		teamChats:      teamChats,
+	}
+}
+
+// Poll fetches the current statuses and sends any changes.
+func (i *Injuries) Poll() {
+	changes, err := i.fantasyService.GetInjuryChanges()
+	if err != nil {
+		slog.Error("Failed to check injury statuses", "error", err)
+		return
+	}
+
+	byChat := make(map[int64][]models.InjuryChange)
+	for _, change := range changes {
+		chatID, ok := i.teamChats[change.Player.TeamID]
+		if !ok {
+			chatID = i.groupChat
+		}
+		byChat[chatID] = append(byChat[chatID], change)
+	}
+
+	for chatID, changes := range byChat {
+		// Starters who can still be benched come first.
+		sort.SliceStable(changes, func(a, b int) bool {
+			return changes[a].Urgent && !changes[b].Urgent
+		})
+
+		slog.Info("Sending injury alerts", "chatID", chatID, "changes", len(changes))
+		if _, err := i.messenger.SendReport(chatID, models.InjuryReport{Changes: changes}); err != nil {
+			slog.Error("Failed to send injury alerts", "chatID", chatID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package live
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/service"
+)
+
+// Messenger posts reports to chats and edits them in place.
+type Messenger interface {
+	SendReport(chatID int64, report models.Report) (int, error)
+	EditReport(chatID int64, messageID int, report models.Report) error
+}
+
+// Watcher reacts to every live scoreboard poll.
+type Watcher interface {
+	Update(scoreboard models.LiveScoreboard)
+}
+
+// Live scores are polled from shortly before an NFL game kicks off until
+// well after it should have ended, to catch overtime and late stat changes.
+// ESPN only lists kickoff times.
+const (
+	windowBefore = 10 * time.Minute
+	windowAfter  = 4 * time.Hour
+)
+
+// Outside game windows the scoreboard is only polled every idleInterval, so
+// results ESPN makes official during the week still get announced.
+const idleInterval = time.Hour
+
+// gamesMaxAge is how long the NFL schedule is used before it is fetched
+// again, to pick up flexed and rescheduled games.
+const gamesMaxAge = time.Hour
+
+// Poller fetches the live scoreboard once per poll and hands it to each
+// watcher, so the board and the alerts share one set of ESPN requests.
+type Poller struct {
+	fantasyService *service.FantasyService
+	location       *time.Location
+	watchers       []Watcher
+
+	mu           sync.Mutex
+	games        []models.ProGame
+	gamesFetched time.Time
+	lastIdlePoll time.Time
+}
+
+func NewPoller(fantasyService *service.FantasyService, location *time.Location, watchers ...Watcher) *Poller {
+	return &Poller{
+		fantasyService: fantasyService,
+		location:       location,
+		watchers:       watchers,
+	}
+}
+
+// Poll updates the watchers if a game is in progress or about to start, and
+// otherwise at most every idleInterval.
+func (p *Poller) Poll() {
+	if !p.due(time.Now()) {
+		return
+	}
+
+	scoreboard, err := p.fantasyService.GetLiveScoreboard()
+	if err != nil {
+		slog.Error("Failed to get live scoreboard", "error", err)
+		return
+	}
+	scoreboard.UpdatedAt = scoreboard.UpdatedAt.In(p.location)
+
+	for _, watcher := range p.watchers {
+		watcher.Update(scoreboard)
+	}
+}
+
+func (p *Poller) due(now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if now.Sub(p.gamesFetched) >= gamesMaxAge {
+		weeks, err := p.fantasyService.GetNearbyProGames()
+		if err != nil {
+			// Without the schedule there's no telling, so poll.
+			slog.Error("Failed to get NFL schedule for live scores", "error", err)
+			return true
+		}
+		p.games = p.games[:0]
+		for _, games := range weeks {
+			p.games = append(p.games, games...)
+		}
+		p.gamesFetched = now
+	}
+
+	if inGameWindow(p.games, now) {
+		return true
+	}
+	if now.Sub(p.lastIdlePoll) >= idleInterval {
+		p.lastIdlePoll = now
+		return true
+	}
+	return false
+}
+
+// inGameWindow reports whether now is between windowBefore a game's kickoff
+// and windowAfter it. Games without a kickoff time yet are left out.
+func inGameWindow(games []models.ProGame, now time.Time) bool {
+	for _, game := range games {
+		if game.StartTimeTBD {
+			continue
+		}
+		if !now.Before(game.Kickoff.Add(-windowBefore)) && now.Before(game.Kickoff.Add(windowAfter)) {
+			return true
+		}
+	}
+	return false
+}
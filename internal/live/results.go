@@ -0,0 +1,153 @@
+package live
+
+import (
+	"log/slog"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/repository/memory"
+	"github.com/omarshaarawi/coachbot/internal/service"
+)
+
+// Results posts a final-whistle message as soon as a matchup can no longer
+// be lost, and a second notice once ESPN marks the winner, which it only
+// does when the scoring period is processed.
+type Results struct {
+	weekScores func(week int) ([]models.Matchup, error)
+	repo       *memory.Repository
+	messenger  Messenger
+	chats      []int64
+	// catchUpFactor is how many times its remaining projection the trailing
+	// team would need to score for a result to still count as open.
+	catchUpFactor float64
+}
+
+func NewResults(fantasyService *service.FantasyService, repo *memory.Repository, messenger Messenger, chats []int64, catchUpFactor float64) *Results {
+	return &Results{
+		weekScores:    fantasyService.GetWeekScores,
+		repo:          repo,
+		messenger:     messenger,
+		chats:         chats,
+		catchUpFactor: catchUpFactor,
+	}
+}
+
+// resultWeeks is how many weeks back official results are still followed
+// up on. Older matchups ESPN never marked completed are given up on.
+const resultWeeks = 2
+
+func (r *Results) Update(scoreboard models.LiveScoreboard) {
+	r.repo.PruneMatchupResults(scoreboard.Week-resultWeeks, scoreboard.Week)
+
+	for _, m := range scoreboard.Matchups {
+		state, _ := r.repo.GetMatchupResultState(m.MatchID)
+		state.MatchID = m.MatchID
+		state.Week = scoreboard.Week
+
+		if m.IsCompleted {
+			if state.Tracked && !state.Official {
+				report := officialResult(scoreboard.Week, m.Home.TeamName, m.Away.TeamName, m.Home.Score, m.Away.Score)
+				state.Official = r.announce(report)
+			}
+		} else {
+			state.Tracked = true
+			if report, ok := r.decided(scoreboard.Week, m); ok && !state.Decided {
+				state.Decided = r.announce(report)
+			}
+		}
+
+		r.repo.SaveMatchupResultState(state)
+	}
+
+	r.checkPreviousWeeks(scoreboard.Week)
+}
+
+// decided reports whether the trailing team can no longer realistically win:
+// it has nobody left to play, or it trails by more than catchUpFactor times
+// what its remaining starters are projected to score.
+func (r *Results) decided(week int, m models.LiveMatchup) (models.MatchupResultReport, bool) {
+	winner, loser := m.Home, m.Away
+	if m.Away.Score > m.Home.Score {
+		winner, loser = m.Away, m.Home
+	}
+
+	deficit := winner.Score - loser.Score
+	if deficit <= 0 {
+		return models.MatchupResultReport{}, false
+	}
+
+	loserPlayersLeft := loser.PlayersYetToPlay + loser.PlayersInProgress
+	if loserPlayersLeft > 0 && deficit <= loser.RemainingProjected*r.catchUpFactor {
+		return models.MatchupResultReport{}, false
+	}
+
+	return models.MatchupResultReport{
+		Stage:            models.ResultDecided,
+		Week:             week,
+		Winner:           winner.TeamName,
+		Loser:            loser.TeamName,
+		WinnerScore:      winner.Score,
+		LoserScore:       loser.Score,
+		LoserPlayersLeft: loserPlayersLeft,
+		LoserRemaining:   loser.RemainingProjected,
+	}, true
+}
+
+// checkPreviousWeeks follows up on matchups from earlier weeks whose
+// official result was not seen before the league moved on to the next week.
+func (r *Results) checkPreviousWeeks(currentWeek int) {
+	weeks := make(map[int][]models.MatchupResultState)
+	for _, state := range r.repo.GetPendingResults() {
+		if state.Week != currentWeek {
+			weeks[state.Week] = append(weeks[state.Week], state)
+		}
+	}
+
+	for week, states := range weeks {
+		matchups, err := r.weekScores(week)
+		if err != nil {
+			slog.Error("Failed to get previous week scores", "week", week, "error", err)
+			continue
+		}
+
+		for _, state := range states {
+			for _, m := range matchups {
+				if m.MatchID != state.MatchID || !m.IsCompleted {
+					continue
+				}
+				state.Official = r.announce(officialResult(week, m.HomeTeam, m.AwayTeam, m.HomeScore, m.AwayScore))
+				r.repo.SaveMatchupResultState(state)
+			}
+		}
+	}
+}
+
+func officialResult(week int, home, away string, homeScore, awayScore float64) models.MatchupResultReport {
+	report := models.MatchupResultReport{
+		Stage:       models.ResultOfficial,
+		Week:        week,
+		Winner:      home,
+		Loser:       away,
+		WinnerScore: homeScore,
+		LoserScore:  awayScore,
+		Tie:         homeScore == awayScore,
+	}
+	if awayScore > homeScore {
+		report.Winner, report.Loser = away, home
+		report.WinnerScore, report.LoserScore = awayScore, homeScore
+	}
+	return report
+}
+
+// announce sends report to every chat and reports whether it reached at
+// least one, so a notice that failed everywhere is retried on the next poll.
+func (r *Results) announce(report models.MatchupResultReport) bool {
+	sent := false
+	for _, chatID := range r.chats {
+		if _, err := r.messenger.SendReport(chatID, report); err != nil {
+			slog.Error("Failed to send matchup result", "chatID", chatID, "error", err)
+			continue
+		}
+		sent = true
+	}
+	return sent
+}
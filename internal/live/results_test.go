@@ -0,0 +1,213 @@
+package live
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/repository/memory"
+)
+
+func TestDecided(t *testing.T) {
+	team := func(name string, score float64, toPlay, playing int, remaining float64) models.LiveTeamScore {
+		return models.LiveTeamScore{
+			TeamName:           name,
+			Score:              score,
+			PlayersYetToPlay:   toPlay,
+			PlayersInProgress:  playing,
+			RemainingProjected: remaining,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		home   models.LiveTeamScore
+		away   models.LiveTeamScore
+		want   bool
+		winner string
+	}{
+		{
+			name: "tied with nobody left",
+			home: team("Home", 100, 0, 0, 0),
+			away: team("Away", 100, 0, 0, 0),
+		},
+		{
+			name:   "trailing team has nobody left",
+			home:   team("Home", 100.5, 3, 0, 40),
+			away:   team("Away", 100, 0, 0, 0),
+			want:   true,
+			winner: "Home",
+		},
+		{
+			name: "deficit exactly at the catch-up threshold",
+			home: team("Home", 125, 0, 0, 0),
+			away: team("Away", 100, 1, 0, 10),
+		},
+		{
+			name:   "deficit just over the catch-up threshold",
+			home:   team("Home", 125.02, 0, 0, 0),
+			away:   team("Away", 100, 1, 0, 10),
+			want:   true,
+			winner: "Home",
+		},
+		{
+			name: "players still playing count as left",
+			home: team("Home", 120, 0, 0, 0),
+			away: team("Away", 100, 0, 1, 10),
+		},
+		{
+			name: "players still to play count as left",
+			home: team("Home", 120, 0, 0, 0),
+			away: team("Away", 100, 2, 0, 10),
+		},
+		{
+			name:   "players left but nothing projected",
+			home:   team("Home", 100, 0, 0, 0),
+			away:   team("Away", 99.9, 1, 0, 0),
+			want:   true,
+			winner: "Home",
+		},
+		{
+			name:   "away team ahead",
+			home:   team("Home", 50, 0, 0, 0),
+			away:   team("Away", 80, 0, 0, 0),
+			want:   true,
+			winner: "Away",
+		},
+	}
+
+	r := &Results{catchUpFactor: 2.5}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, ok := r.decided(5, models.LiveMatchup{MatchID: 1, Home: tt.home, Away: tt.away})
+			if ok != tt.want {
+				t.Fatalf("decided = %v, want %v", ok, tt.want)
+			}
+			if ok && (report.Winner != tt.winner || report.Stage != models.ResultDecided || report.Week != 5) {
+				t.Errorf("report = %+v, want %s winning week 5", report, tt.winner)
+			}
+		})
+	}
+}
+
+func TestResultsUpdate(t *testing.T) {
+	live := func(home, away float64, completed bool) models.LiveScoreboard {
+		return models.LiveScoreboard{
+			Week: 5,
+			Matchups: []models.LiveMatchup{{
+				MatchID:     1,
+				IsCompleted: completed,
+				Home:        models.LiveTeamScore{TeamName: "Home", Score: home},
+				Away:        models.LiveTeamScore{TeamName: "Away", Score: away, PlayersYetToPlay: 1, RemainingProjected: 10},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		polls []models.LiveScoreboard
+		want  []models.MatchupResultStage
+	}{
+		{
+			name:  "still open",
+			polls: []models.LiveScoreboard{live(110, 100, false)},
+		},
+		{
+			name:  "decided once, then official",
+			polls: []models.LiveScoreboard{live(140, 100, false), live(141, 100, false), live(141, 100, true), live(141, 100, true)},
+			want:  []models.MatchupResultStage{models.ResultDecided, models.ResultOfficial},
+		},
+		{
+			name:  "completed without being followed live",
+			polls: []models.LiveScoreboard{live(141, 100, true)},
+		},
+		{
+			name:  "official after an open finish",
+			polls: []models.LiveScoreboard{live(110, 100, false), live(110, 108, true)},
+			want:  []models.MatchupResultStage{models.ResultOfficial},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messenger := &fakeMessenger{}
+			r := &Results{repo: memory.NewRepository(), messenger: messenger, chats: []int64{-100}, catchUpFactor: 2.5}
+			for _, scoreboard := range tt.polls {
+				r.Update(scoreboard)
+			}
+			if got := stages(messenger.reports); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("announced %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPreviousWeeks(t *testing.T) {
+	repo := memory.NewRepository()
+	for _, state := range []models.MatchupResultState{
+		{MatchID: 1, Week: 3, Tracked: true},
+		{MatchID: 2, Week: 3, Tracked: true},
+		{MatchID: 3, Week: 3, Tracked: true, Official: true},
+		{MatchID: 4, Week: 2, Tracked: true},
+		{MatchID: 5, Week: 4, Tracked: true},
+	} {
+		repo.SaveMatchupResultState(state)
+	}
+
+	var fetched []int
+	messenger := &fakeMessenger{}
+	r := &Results{
+		weekScores: func(week int) ([]models.Matchup, error) {
+			fetched = append(fetched, week)
+			if week == 2 {
+				return nil, errors.New("ESPN is down")
+			}
+			return []models.Matchup{
+				{MatchID: 1, HomeTeam: "A", AwayTeam: "B", HomeScore: 90, AwayScore: 95, IsCompleted: true},
+				{MatchID: 2, HomeTeam: "C", AwayTeam: "D", HomeScore: 80, AwayScore: 70},
+				{MatchID: 3, HomeTeam: "E", AwayTeam: "F", HomeScore: 80, AwayScore: 70, IsCompleted: true},
+			}, nil
+		},
+		repo:      repo,
+		messenger: messenger,
+		chats:     []int64{-100},
+	}
+	r.checkPreviousWeeks(4)
+
+	slices.Sort(fetched)
+	if !reflect.DeepEqual(fetched, []int{2, 3}) {
+		t.Errorf("fetched weeks %v, want 2 and 3", fetched)
+	}
+	if len(messenger.reports) != 1 {
+		t.Fatalf("announced %d results, want 1", len(messenger.reports))
+	}
+	report := messenger.reports[0].(models.MatchupResultReport)
+	if report.Stage != models.ResultOfficial || report.Week != 3 || report.Winner != "B" || report.WinnerScore != 95 {
+		t.Errorf("report = %+v, want B's official week 3 win", report)
+	}
+
+	for _, tt := range []struct {
+		matchID  int
+		official bool
+	}{
+		{matchID: 1, official: true},
+		{matchID: 2, official: false},
+		{matchID: 4, official: false},
+		{matchID: 5, official: false},
+	} {
+		state, _ := repo.GetMatchupResultState(tt.matchID)
+		if state.Official != tt.official {
+			t.Errorf("matchup %d official = %v, want %v", tt.matchID, state.Official, tt.official)
+		}
+	}
+}
+
+func stages(reports []models.Report) []models.MatchupResultStage {
+	var stages []models.MatchupResultStage
+	for _, report := range reports {
+		stages = append(stages, report.(models.MatchupResultReport).Stage)
+	}
+	return stages
+}
@@ -0,0 +1,41 @@
+package live
+
+import (
+	"log/slog"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/service"
+)
+
+// Transactions posts each new add, drop, waiver claim and accepted trade to
+// the league chat.
+type Transactions struct {
+	fantasyService *service.FantasyService
+	messenger      Messenger
+	chatID         int64
+}
+
+func NewTransactions(fantasyService *service.FantasyService, messenger Messenger, chatID int64) *Transactions {
+	return &Transactions{
+		fantasyService: fantasyService,
+		messenger:      messenger,
+		chatID:         chatID,
+	}
+}
+
+// Poll fetches the latest transactions and sends the ones not posted yet.
+func (t *Transactions) Poll() {
+	transactions, err := t.fantasyService.GetNewTransactions()
+	if err != nil {
+		slog.Error("Failed to check transactions", "error", err)
+		return
+	}
+	if len(transactions) == 0 {
+		return
+	}
+
+	slog.Info("Sending transactions", "count", len(transactions))
+	if _, err := t.messenger.SendReport(t.chatID, models.TransactionsReport{Transactions: transactions}); err != nil {
+		slog.Error("Failed to send transactions", "error", err)
+	}
+}
@@ -0,0 +1,39 @@
+package live
+
+import (
+	"log/slog"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/service"
+)
+
+// Watchlist privately notifies members about changes to the players on
+// their watchlists. Telegram only delivers these once the member has started
+// a private chat with the bot.
+type Watchlist struct {
+	fantasyService *service.FantasyService
+	messenger      Messenger
+}
+
+func NewWatchlist(fantasyService *service.FantasyService, messenger Messenger) *Watchlist {
+	return &Watchlist{
+		fantasyService: fantasyService,
+		messenger:      messenger,
+	}
+}
+
+// Poll checks watched players and sends each member their changes.
+func (w *Watchlist) Poll() {
+	events, err := w.fantasyService.CheckWatchedPlayers()
+	if err != nil {
+		slog.Error("Failed to check watched players", "error", err)
+		return
+	}
+
+	for userID, userEvents := range events {
+		slog.Info("Sending watchlist alerts", "userID", userID, "events", len(userEvents))
+		if _, err := w.messenger.SendReport(userID, models.WatchEventsReport{Events: userEvents}); err != nil {
+			slog.Error("Failed to send watchlist alerts", "userID", userID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package metrics holds the Prometheus metrics the bot exports on /metrics.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "coachbot"
+
+var (
+	// ESPNRequests counts ESPN API requests by view and HTTP status, with
+	// "error" for requests that got no response.
+	ESPNRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "espn_requests_total",
+		Help:      "ESPN API requests by view and status.",
+	}, []string{"view", "status"})
+
+	ESPNRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "espn_request_duration_seconds",
+		Help:      "ESPN API request latency by view.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 5, 10},
+	}, []string{"view"})
+
+	ESPNLastSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "espn_last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful ESPN API fetch by view.",
+	}, []string{"view"})
+
+	// TelegramRequests counts sends, edits and deletes by result: "sent",
+	// "failed", or "retried" and "rate_limited" for attempts that are tried
+	// again.
+	TelegramRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "telegram_requests_total",
+		Help:      "Telegram message requests by kind and result.",
+	}, []string{"kind", "result"})
+
+	Commands = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "commands_total",
+		Help:      "Bot commands received by command name.",
+	}, []string{"command"})
+
+	JobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_runs_total",
+		Help:      "Scheduled job run attempts by job and status.",
+	}, []string{"job", "status"})
+
+	// JobFailures counts runs that were given up on after all retries.
+	JobFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_failures_total",
+		Help:      "Scheduled job runs that failed after all retries, by job.",
+	}, []string{"job"})
+
+	CacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Cache lookups by cache and result (hit or miss).",
+	}, []string{"cache", "result"})
+)
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -1,18 +1,42 @@
 package models
 
 type LeagueResponse struct {
-	ID              int      `json:"id"`
-	ScoringPeriodID int      `json:"scoringPeriodId"`
-	SeasonID        int      `json:"seasonId"`
-	SegmentID       int      `json:"segmentId"`
-	Status          Status   `json:"status"`
-	Teams           []Team   `json:"teams"`
-	Settings        Settings `json:"settings"`
+	ID              int         `json:"id"`
+	ScoringPeriodID int         `json:"scoringPeriodId"`
+	SeasonID        int         `json:"seasonId"`
+	SegmentID       int         `json:"segmentId"`
+	Status          Status      `json:"status"`
+	Teams           []Team      `json:"teams"`
+	Settings        Settings    `json:"settings"`
+	DraftDetail     DraftDetail `json:"draftDetail"`
+}
+
+type DraftDetail struct {
+	Drafted    bool `json:"drafted"`
+	InProgress bool `json:"inProgress"`
 }
 
 type Settings struct {
-	Name string `json:"name"`
-	Size int    `json:"size"`
+	Name             string                  `json:"name"`
+	Size             int                     `json:"size"`
+	ScoringSettings  ScoringSettingsResponse `json:"scoringSettings"`
+	ScheduleSettings ScheduleSettings        `json:"scheduleSettings"`
+}
+
+type ScheduleSettings struct {
+	// MatchupPeriodCount is the number of regular season matchup periods.
+	MatchupPeriodCount int `json:"matchupPeriodCount"`
+	PlayoffTeamCount   int `json:"playoffTeamCount"`
+}
+
+type ScoringSettingsResponse struct {
+	ScoringItems []ScoringItemResponse `json:"scoringItems"`
+}
+
+type ScoringItemResponse struct {
+	StatID          int                `json:"statId"`
+	Points          float64            `json:"points"`
+	PointsOverrides map[string]float64 `json:"pointsOverrides"`
 }
 
 type Status struct {
@@ -54,10 +78,12 @@ type ScoreboardResponse struct {
 }
 
 type MatchupScore struct {
-	ID     int       `json:"id"`
-	Away   TeamScore `json:"away"`
-	Home   TeamScore `json:"home"`
-	Winner string    `json:"winner"`
+	ID              int       `json:"id"`
+	MatchupPeriodID int       `json:"matchupPeriodId"`
+	PlayoffTierType string    `json:"playoffTierType"`
+	Away            TeamScore `json:"away"`
+	Home            TeamScore `json:"home"`
+	Winner          string    `json:"winner"`
 }
 
 type TeamScore struct {
@@ -84,6 +110,7 @@ type PlayerCardResponse struct {
 type PlayerPoolEntry struct {
 	ID               int     `json:"id"`
 	OnTeamID         int     `json:"onTeamId"`
+	Status           string  `json:"status"`
 	Player           Player  `json:"player"`
 	AppliedStatTotal float64 `json:"appliedStatTotal"`
 }
@@ -99,12 +126,15 @@ type Player struct {
 }
 
 type Ownership struct {
-	PercentOwned float64 `json:"percentOwned"`
+	PercentOwned  float64 `json:"percentOwned"`
+	PercentChange float64 `json:"percentChange"`
 }
 
 type Stat struct {
 	StatSourceID    int                `json:"statSourceId"`
+	StatSplitTypeID int                `json:"statSplitTypeId"`
 	ScoringPeriodID int                `json:"scoringPeriodId"`
 	AppliedTotal    float64            `json:"appliedTotal"`
 	AppliedStats    map[string]float64 `json:"appliedStats"`
+	RawStats        map[string]float64 `json:"stats"`
 }
@@ -11,9 +11,22 @@ type LeagueMetadata struct {
 	FirstWeek            int
 	LastWeek             int
 	IsActive             bool
+	RegularSeasonWeeks   int
+	PlayoffTeams         int
+	DraftComplete        bool
+	Scoring              ScoringSettings
 	LastUpdated          time.Time
 }
 
+type SeasonPhase string
+
+const (
+	PhasePreseason     SeasonPhase = "preseason"
+	PhaseRegularSeason SeasonPhase = "regular_season"
+	PhasePlayoffs      SeasonPhase = "playoffs"
+	PhaseOffseason     SeasonPhase = "offseason"
+)
+
 type TeamStanding struct {
 	Rank          int
 	TeamID        int
@@ -29,6 +42,7 @@ type TeamStanding struct {
 }
 
 type WhoHasResult struct {
+	PlayerID     int
 	PlayerName   string
 	TeamName     string
 	TeamID       int
@@ -39,6 +53,22 @@ type WhoHasResult struct {
 	Points       float64
 	IsProjected  bool
 	LineupSlot   string
+	Candidates   []PlayerCandidate
+}
+
+// PlayerCandidate is one of several players matching an ambiguous search.
+type PlayerCandidate struct {
+	PlayerID int
+	Name     string
+	Position string
+	ProTeam  string
+	TeamName string
+}
+
+// TeamCandidate is one of several fantasy teams matching an ambiguous search.
+type TeamCandidate struct {
+	TeamID   int
+	TeamName string
 }
 
 type PlayerToMonitor struct {
@@ -53,6 +83,7 @@ type TeamMonitorReport struct {
 }
 
 type PlayersToMonitorReport struct {
+	Week  int
 	Teams []TeamMonitorReport
 }
 
@@ -75,9 +106,47 @@ type Trophy struct {
 	Value    float64
 }
 
+// FinalScoreReport is a week's results and trophies. Discrepancies lists
+// teams whose ESPN total doesn't match their starters' recalculated points.
 type FinalScoreReport struct {
-	Matchups []Matchup
-	Trophies []Trophy
+	Week          int
+	Matchups      []Matchup
+	Trophies      []Trophy
+	Discrepancies []ScoreDiscrepancy
+}
+
+type ScoreChange struct {
+	Team   string
+	Before float64
+	After  float64
+}
+
+type TrophyChange struct {
+	Before Trophy
+	After  Trophy
+}
+
+type ResultFlip struct {
+	HomeTeam  string
+	AwayTeam  string
+	HomeScore float64
+	AwayScore float64
+	OldWinner string
+	NewWinner string
+}
+
+// StatCorrectionReport lists what changed in a week's results after it was
+// announced. Corrected is the week's results with the changes applied.
+type StatCorrectionReport struct {
+	Week           int
+	ScoreChanges   []ScoreChange
+	TrophyChanges  []TrophyChange
+	FlippedResults []ResultFlip
+	Corrected      FinalScoreReport
+}
+
+func (r StatCorrectionReport) HasChanges() bool {
+	return len(r.ScoreChanges) > 0 || len(r.TrophyChanges) > 0 || len(r.FlippedResults) > 0
 }
 
 type CloseGame struct {
@@ -96,9 +165,393 @@ type RosterPlayer struct {
 	IsStarter    bool
 	LineupSlot   string
 	InjuryStatus string
+	// Breakdown is where this week's points came from, once the player has
+	// stats.
+	Breakdown []StatLineItem
 }
 
 type TeamRoster struct {
-	TeamName string
-	Players  []RosterPlayer
+	TeamID     int
+	TeamName   string
+	Players    []RosterPlayer
+	Candidates []TeamCandidate
+}
+
+type ProGame struct {
+	ID            int
+	HomeProTeamID int
+	AwayProTeamID int
+	HomeProTeam   string
+	AwayProTeam   string
+	Kickoff       time.Time
+	StartTimeTBD  bool
+}
+
+type LiveTeamScore struct {
+	TeamID            int
+	TeamName          string
+	Score             float64
+	Projected         float64
+	PlayersYetToPlay  int
+	PlayersInProgress int
+	// RemainingProjected is what the starters who have not finished are
+	// still projected to add.
+	RemainingProjected float64
+}
+
+type LiveMatchup struct {
+	MatchID     int
+	Home        LiveTeamScore
+	Away        LiveTeamScore
+	IsCompleted bool
+}
+
+type LiveScoreboard struct {
+	Week          int
+	ScoringPeriod int
+	Matchups      []LiveMatchup
+	// GamesInProgress is true while any starter's NFL game is being played.
+	GamesInProgress bool
+	// GamesRemaining is true while any starter's NFL game has not kicked off.
+	GamesRemaining bool
+	UpdatedAt      time.Time
+}
+
+// MatchupSnapshot is the state of a matchup at the previous live poll.
+// Leader is the side that last held the lead, 1 for home and -1 for away, or
+// 0 if nobody has led yet; a tie doesn't change it.
+type MatchupSnapshot struct {
+	MatchID        int
+	HomeScore      float64
+	AwayScore      float64
+	HomeProjected  float64
+	AwayProjected  float64
+	HomeMaxDeficit float64
+	AwayMaxDeficit float64
+	Leader         int
+}
+
+type LiveEventKind string
+
+const (
+	EventLeadChange     LiveEventKind = "lead_change"
+	EventLateLead       LiveEventKind = "late_lead"
+	EventProjectionFlip LiveEventKind = "projection_flip"
+	EventComeback       LiveEventKind = "comeback"
+)
+
+// LiveEvent is something notable that happened in a matchup between two
+// live polls. Team is the side the event is about, e.g. the new leader.
+type LiveEvent struct {
+	Kind              LiveEventKind
+	MatchID           int
+	Team              string
+	Opponent          string
+	TeamScore         float64
+	OpponentScore     float64
+	TeamProjected     float64
+	OpponentProjected float64
+	PlayersLeft       int
+	Deficit           float64
+}
+
+type LiveEventsReport struct {
+	Events []LiveEvent
+}
+
+// MatchupResultState tracks which result notices were posted for a matchup.
+type MatchupResultState struct {
+	MatchID int
+	Week    int
+	// Tracked is set once the matchup was seen undecided, so results are only
+	// announced for matchups followed live.
+	Tracked  bool
+	Decided  bool
+	Official bool
+}
+
+type MatchupResultStage string
+
+const (
+	ResultDecided  MatchupResultStage = "decided"
+	ResultOfficial MatchupResultStage = "official"
+)
+
+// MatchupResultReport announces that a matchup is decided or official.
+type MatchupResultReport struct {
+	Stage       MatchupResultStage
+	Week        int
+	Winner      string
+	Loser       string
+	WinnerScore float64
+	LoserScore  float64
+	Tie         bool
+	// LoserPlayersLeft and LoserRemaining explain why a result is decided.
+	LoserPlayersLeft int
+	LoserRemaining   float64
+}
+
+// PlayerStatus is a rostered player's injury status at the time of a poll.
+type PlayerStatus struct {
+	PlayerID     int
+	Name         string
+	Position     string
+	ProTeam      string
+	TeamID       int
+	TeamName     string
+	InjuryStatus string
+	IsStarter    bool
+	GameStarted  bool
+}
+
+// InjuryChange is a rostered player whose injury status changed since the
+// previous poll. Urgent is set when the player is in the starting lineup and
+// their game has not kicked off, so there is still time to swap them out.
+type InjuryChange struct {
+	Player    PlayerStatus
+	OldStatus string
+	NewStatus string
+	Urgent    bool
+}
+
+type InjuryReport struct {
+	Changes []InjuryChange
+}
+
+type TransactionKind string
+
+const (
+	TransactionFreeAgent TransactionKind = "free_agent"
+	TransactionWaiver    TransactionKind = "waiver"
+	TransactionTrade     TransactionKind = "trade"
+)
+
+type TransactionAction string
+
+const (
+	TransactionAdd    TransactionAction = "add"
+	TransactionDrop   TransactionAction = "drop"
+	TransactionTraded TransactionAction = "trade"
+)
+
+// TransactionItem is one player moving in a transaction. FromTeam and ToTeam
+// are empty for the free agent and waiver pool.
+type TransactionItem struct {
+	Action   TransactionAction
+	Player   PlayerCandidate
+	FromTeam string
+	ToTeam   string
+}
+
+type Transaction struct {
+	ID        string
+	Kind      TransactionKind
+	TeamID    int
+	TeamName  string
+	BidAmount int
+	Date      time.Time
+	Items     []TransactionItem
+}
+
+// TransactionsReport lists roster moves. TeamID and Team are set when the
+// list was filtered to one team.
+type TransactionsReport struct {
+	TeamID       int
+	Team         string
+	Transactions []Transaction
+}
+
+// FreeAgent is an available player. Status is "FA" for free agents and "WA"
+// for players still on waivers.
+type FreeAgent struct {
+	PlayerID       int
+	Name           string
+	Position       string
+	ProTeam        string
+	Status         string
+	RestOfSeason   float64
+	WeekProjected  float64
+	LastWeekPoints float64
+	PercentOwned   float64
+	PercentChange  float64
+}
+
+// FreeAgentReport lists the best available players. Position is empty when
+// the list covers all positions.
+type FreeAgentReport struct {
+	Week     int
+	Position string
+	Players  []FreeAgent
+}
+
+type Availability string
+
+const (
+	Rostered      Availability = "rostered"
+	OnWaivers     Availability = "waivers"
+	FreeAgentPool Availability = "free_agent"
+)
+
+// PlayerState is where a player stands in the league: who rosters them, or
+// whether they can be claimed, and their injury status.
+type PlayerState struct {
+	PlayerID     int
+	Name         string
+	Position     string
+	ProTeam      string
+	TeamID       int
+	TeamName     string
+	Availability Availability
+	InjuryStatus string
+}
+
+type WatchedPlayer struct {
+	PlayerID int
+	Name     string
+}
+
+type WatchlistReport struct {
+	Players []WatchedPlayer
+}
+
+type WatchEventKind string
+
+const (
+	WatchDropped WatchEventKind = "dropped"
+	WatchInjury  WatchEventKind = "injury"
+	WatchOffIR   WatchEventKind = "off_ir"
+)
+
+// WatchEvent is a change to a watched player. FromTeam is set for drops,
+// OldStatus and NewStatus for injury changes.
+type WatchEvent struct {
+	Kind      WatchEventKind
+	Player    PlayerState
+	FromTeam  string
+	OldStatus string
+	NewStatus string
+}
+
+type WatchEventsReport struct {
+	Events []WatchEvent
+}
+
+type PlayerWeek struct {
+	Week      int
+	Points    float64
+	Projected float64
+	Played    bool
+}
+
+// StatLineItem is one stat category with the points it was worth. Value is
+// zero for the catch-all "Other" category.
+type StatLineItem struct {
+	Category string
+	Value    float64
+	Points   float64
+}
+
+// PlayerGameLog is a player's season week by week, plus their stat line for
+// Week. StatLineProjected is set when the stat line is a projection because
+// the player hasn't played yet. StatLinePoints is the stat line recalculated
+// with the league's scoring and ESPNPoints what ESPN credited for it.
+type PlayerGameLog struct {
+	PlayerID          int
+	Name              string
+	Position          string
+	ProTeam           string
+	TeamName          string
+	Week              int
+	SeasonPoints      float64
+	Weeks             []PlayerWeek
+	StatLine          []StatLineItem
+	StatLineProjected bool
+	StatLinePoints    float64
+	ESPNPoints        float64
+}
+
+// JobStatus describes a scheduled report job. Report is what the job posts in
+// the current season phase, and InPhase is false when it doesn't run in it.
+// NextRun is zero when the job is disabled.
+type JobStatus struct {
+	Name     string
+	Report   string
+	Schedule string
+	Phases   []string
+	InPhase  bool
+	Enabled  bool
+	Paused   bool
+	Approve  bool
+	NextRun  time.Time
+	LastRun  JobRun
+}
+
+type JobRunStatus string
+
+const (
+	JobRunSucceeded JobRunStatus = "succeeded"
+	JobRunFailed    JobRunStatus = "failed"
+	JobRunSkipped   JobRunStatus = "skipped"
+	// JobRunPending is a run whose report is waiting for an admin to
+	// approve it.
+	JobRunPending JobRunStatus = "pending"
+)
+
+// JobRun is one attempt at a scheduled job run. Scheduled is when the run
+// was due, so retries and catch-up runs share it with the original attempt.
+type JobRun struct {
+	Job       string
+	Report    string
+	Scheduled time.Time
+	Started   time.Time
+	Duration  time.Duration
+	Attempt   int
+	CatchUp   bool
+	Status    JobRunStatus
+	Error     string
+}
+
+type JobsReport struct {
+	Phase SeasonPhase
+	Jobs  []JobStatus
+}
+
+// OutboundKey identifies a scheduled post: a report for a week in a chat.
+// Run is only set for reports posted more than once a week, like the
+// scoreboard, and tells those posts apart.
+type OutboundKey struct {
+	Report string
+	Week   int
+	ChatID int64
+	Run    time.Time
+}
+
+// OutboundMessage records the Telegram messages a scheduled post was sent
+// as. Name is the schedule's name for the report.
+type OutboundMessage struct {
+	Key        OutboundKey
+	Job        string
+	Name       string
+	MessageIDs []int
+	Sent       time.Time
+}
+
+// PlayoffMatchup is a winners bracket game. AwayTeam is empty for a bye.
+type PlayoffMatchup struct {
+	HomeTeam  string
+	AwayTeam  string
+	HomeSeed  int
+	AwaySeed  int
+	HomeScore float64
+	AwayScore float64
+	Winner    string
+}
+
+type PlayoffRound struct {
+	Week     int
+	Matchups []PlayoffMatchup
+}
+
+type PlayoffBracketReport struct {
+	Rounds []PlayoffRound
 }
@@ -0,0 +1,52 @@
+package models
+
+// Report is a typed result produced by the service layer. Reports carry data
+// only; the render package decides how they look in each output format.
+type Report interface {
+	ReportType() string
+}
+
+// Message is a free-form text report, used for help text, prompts and errors.
+type Message struct {
+	Text string
+}
+
+type StandingsReport struct {
+	Standings []TeamStanding
+}
+
+type ScoresReport struct {
+	Week     int
+	Matchups []Matchup
+}
+
+type MatchupsReport struct {
+	Week     int
+	Matchups []Matchup
+}
+
+type CloseGamesReport struct {
+	Games []CloseGame
+}
+
+func (Message) ReportType() string                { return "message" }
+func (StandingsReport) ReportType() string        { return "standings" }
+func (ScoresReport) ReportType() string           { return "scores" }
+func (MatchupsReport) ReportType() string         { return "matchups" }
+func (CloseGamesReport) ReportType() string       { return "close_games" }
+func (WhoHasResult) ReportType() string           { return "whohas" }
+func (PlayersToMonitorReport) ReportType() string { return "players_to_monitor" }
+func (FinalScoreReport) ReportType() string       { return "final_scores" }
+func (TeamRoster) ReportType() string             { return "team_roster" }
+func (LiveScoreboard) ReportType() string         { return "live_scoreboard" }
+func (LiveEventsReport) ReportType() string       { return "live_events" }
+func (MatchupResultReport) ReportType() string    { return "matchup_result" }
+func (StatCorrectionReport) ReportType() string   { return "stat_corrections" }
+func (InjuryReport) ReportType() string           { return "injury_changes" }
+func (TransactionsReport) ReportType() string     { return "transactions" }
+func (FreeAgentReport) ReportType() string        { return "free_agents" }
+func (WatchlistReport) ReportType() string        { return "watchlist" }
+func (WatchEventsReport) ReportType() string      { return "watch_events" }
+func (PlayerGameLog) ReportType() string          { return "player_game_log" }
+func (JobsReport) ReportType() string             { return "jobs" }
+func (PlayoffBracketReport) ReportType() string   { return "playoff_bracket" }
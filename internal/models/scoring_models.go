@@ -0,0 +1,69 @@
+package models
+
+import "math"
+
+// ScoringItem is what one stat is worth. SlotOverrides replaces Points for
+// players in the given lineup slots, e.g. interceptions for a D/ST. Bonus
+// marks stats that count thresholds reached rather than plays, like a
+// 300-yard passing game or a points-allowed tier.
+type ScoringItem struct {
+	StatID        int
+	Label         string
+	Points        float64
+	SlotOverrides map[int]float64
+	Bonus         bool
+}
+
+// PointsFor returns what one of the stat is worth for a player in slot.
+func (i ScoringItem) PointsFor(slot int) float64 {
+	if points, ok := i.SlotOverrides[slot]; ok {
+		return points
+	}
+	return i.Points
+}
+
+// ScoringSettings is the league's scoring, keyed by stat ID. Order lists the
+// stat IDs in the order breakdowns are shown.
+type ScoringSettings struct {
+	Items map[int]ScoringItem
+	Order []int
+}
+
+// Calculate recomputes fantasy points from raw stats keyed by stat ID, for a
+// player in the given position slot. It returns the total and the points
+// earned per stat, in Order, leaving out stats worth nothing.
+func (s ScoringSettings) Calculate(stats map[int]float64, slot int) (float64, []StatLineItem) {
+	var total float64
+	var breakdown []StatLineItem
+
+	for _, statID := range s.Order {
+		item := s.Items[statID]
+		value := stats[statID]
+		points := value * item.PointsFor(slot)
+		if points == 0 {
+			continue
+		}
+
+		total += points
+		lineItem := StatLineItem{Category: item.Label, Value: value, Points: roundPoints(points)}
+		if item.Bonus {
+			lineItem.Value = 0
+		}
+		breakdown = append(breakdown, lineItem)
+	}
+
+	return roundPoints(total), breakdown
+}
+
+func roundPoints(points float64) float64 {
+	return math.Round(points*100) / 100
+}
+
+// ScoreDiscrepancy is a team whose ESPN total doesn't match the total
+// recalculated from its starters' stats.
+type ScoreDiscrepancy struct {
+	TeamID     int
+	TeamName   string
+	ESPN       float64
+	Calculated float64
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScoringSettingsCalculate(t *testing.T) {
+	settings := ScoringSettings{
+		Items: map[int]ScoringItem{
+			3:  {StatID: 3, Label: "Pass Yds", Points: 0.04},
+			4:  {StatID: 4, Label: "Pass TD", Points: 4},
+			20: {StatID: 20, Label: "Int", Points: -2, SlotOverrides: map[int]float64{16: 2}},
+			17: {StatID: 17, Label: "300+ Pass Yds", Points: 3, Bonus: true},
+			24: {StatID: 24, Label: "Rush Yds", Points: 0.1},
+		},
+		Order: []int{3, 4, 20, 17, 24},
+	}
+
+	tests := []struct {
+		name      string
+		stats     map[int]float64
+		slot      int
+		total     float64
+		breakdown []StatLineItem
+	}{
+		{
+			name:  "no stats",
+			stats: map[int]float64{},
+			slot:  0,
+		},
+		{
+			name:  "base points in order",
+			stats: map[int]float64{24: 12, 3: 250, 4: 2},
+			slot:  0,
+			total: 19.2,
+			breakdown: []StatLineItem{
+				{Category: "Pass Yds", Value: 250, Points: 10},
+				{Category: "Pass TD", Value: 2, Points: 8},
+				{Category: "Rush Yds", Value: 12, Points: 1.2},
+			},
+		},
+		{
+			name:  "bonus shows points without a value",
+			stats: map[int]float64{3: 310, 17: 1},
+			slot:  0,
+			total: 15.4,
+			breakdown: []StatLineItem{
+				{Category: "Pass Yds", Value: 310, Points: 12.4},
+				{Category: "300+ Pass Yds", Points: 3},
+			},
+		},
+		{
+			name:  "slot override replaces points",
+			stats: map[int]float64{20: 2},
+			slot:  16,
+			total: 4,
+			breakdown: []StatLineItem{
+				{Category: "Int", Value: 2, Points: 4},
+			},
+		},
+		{
+			name:  "other slots keep base points",
+			stats: map[int]float64{20: 2},
+			slot:  0,
+			total: -4,
+			breakdown: []StatLineItem{
+				{Category: "Int", Value: 2, Points: -4},
+			},
+		},
+		{
+			name:  "stats outside the settings are left out",
+			stats: map[int]float64{4: 1, 99: 7},
+			slot:  0,
+			total: 4,
+			breakdown: []StatLineItem{
+				{Category: "Pass TD", Value: 1, Points: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, breakdown := settings.Calculate(tt.stats, tt.slot)
+			if total != tt.total {
+				t.Errorf("total = %v, want %v", total, tt.total)
+			}
+			if !reflect.DeepEqual(breakdown, tt.breakdown) {
+				t.Errorf("breakdown = %+v, want %+v", breakdown, tt.breakdown)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package render
+
+import "strings"
+
+// Style is the inline formatting applied to a span of text.
+type Style int
+
+const (
+	Regular Style = iota
+	Bold
+	Italic
+)
+
+// Span is a run of text with a single style. Text is never pre-escaped;
+// each format escapes it for its own syntax.
+type Span struct {
+	Text  string
+	Style Style
+}
+
+// Line is one line of output made of styled spans.
+type Line []Span
+
+// Section is a group of lines. Sections are separated by a blank line and
+// are the unit messages are split on.
+type Section []Line
+
+// Document is the format-independent layout of a report.
+type Document []Section
+
+func text(s string) Span {
+	return Span{Text: s}
+}
+
+func bold(s string) Span {
+	return Span{Text: s, Style: Bold}
+}
+
+func italic(s string) Span {
+	return Span{Text: s, Style: Italic}
+}
+
+func line(spans ...Span) Line {
+	return Line(spans)
+}
+
+// paragraph turns free text into a section, one line per newline.
+func paragraph(s string) Section {
+	var section Section
+	for _, l := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		section = append(section, line(text(l)))
+	}
+	return section
+}
@@ -0,0 +1,709 @@
+package render
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// Layout arranges a report into a format-independent document.
+func Layout(report models.Report) Document {
+	switch r := report.(type) {
+	case Part:
+		return r.Doc
+	case models.Message:
+		return Document{paragraph(r.Text)}
+	case models.StandingsReport:
+		return layoutStandings(r)
+	case models.PlayoffBracketReport:
+		return layoutPlayoffBracket(r)
+	case models.ScoresReport:
+		return layoutScores(r)
+	case models.MatchupsReport:
+		return layoutMatchups(r)
+	case models.CloseGamesReport:
+		return layoutCloseGames(r)
+	case models.WhoHasResult:
+		return layoutWhoHas(r)
+	case models.PlayersToMonitorReport:
+		return layoutPlayersToMonitor(r)
+	case models.FinalScoreReport:
+		return layoutFinalScores(r)
+	case models.TeamRoster:
+		return layoutTeamRoster(r)
+	case models.LiveScoreboard:
+		return layoutLiveScoreboard(r)
+	case models.LiveEventsReport:
+		return layoutLiveEvents(r)
+	case models.MatchupResultReport:
+		return layoutMatchupResult(r)
+	case models.StatCorrectionReport:
+		return layoutStatCorrections(r)
+	case models.InjuryReport:
+		return layoutInjuries(r)
+	case models.TransactionsReport:
+		return layoutTransactions(r)
+	case models.FreeAgentReport:
+		return layoutFreeAgents(r)
+	case models.WatchlistReport:
+		return layoutWatchlist(r)
+	case models.WatchEventsReport:
+		return layoutWatchEvents(r)
+	case models.PlayerGameLog:
+		return layoutPlayerGameLog(r)
+	case models.JobsReport:
+		return layoutJobs(r)
+	default:
+		return Document{paragraph(fmt.Sprintf("Unsupported report type: %s", report.ReportType()))}
+	}
+}
+
+func layoutStandings(r models.StandingsReport) Document {
+	doc := Document{{line(text("🏆 "), bold("Current Standings"))}}
+
+	for _, team := range r.Standings {
+		doc = append(doc, Section{
+			line(text(fmt.Sprintf("%d. ", team.Rank)), bold(team.TeamName)),
+			line(text(fmt.Sprintf("   Record: %d-%d-%d", team.Wins, team.Losses, team.Ties))),
+			line(text(fmt.Sprintf("   Points For: %.2f", team.PointsFor))),
+			line(text(fmt.Sprintf("   Points Against: %.2f", team.PointsAgainst))),
+		})
+	}
+
+	return doc
+}
+
+func layoutPlayoffBracket(r models.PlayoffBracketReport) Document {
+	doc := Document{{line(text("🏆 "), bold("Playoff Bracket"))}}
+
+	for i, round := range r.Rounds {
+		title := fmt.Sprintf("Round %d", i+1)
+		if i == len(r.Rounds)-1 {
+			title = "Championship"
+		}
+		section := Section{line(bold(title), text(fmt.Sprintf(" (Week %d)", round.Week)))}
+
+		if len(round.Matchups) == 0 {
+			section = append(section, line(text("   "), italic("To be decided")))
+		}
+		for _, m := range round.Matchups {
+			if m.AwayTeam == "" {
+				section = append(section, line(text(fmt.Sprintf("   (%d) %s: bye", m.HomeSeed, m.HomeTeam))))
+				continue
+			}
+			section = append(section, line(text(fmt.Sprintf("   (%d) %s %.2f - %.2f %s (%d)",
+				m.HomeSeed, m.HomeTeam, m.HomeScore, m.AwayScore, m.AwayTeam, m.AwaySeed))))
+			if m.Winner != "" {
+				section = append(section, line(text("   "), italic(m.Winner+" advances")))
+			}
+		}
+		doc = append(doc, section)
+	}
+
+	return doc
+}
+
+func layoutScores(r models.ScoresReport) Document {
+	doc := Document{{line(text("🏈 "), bold(fmt.Sprintf("Week %d Current Scores", r.Week)))}}
+
+	for _, m := range r.Matchups {
+		section := Section{
+			line(bold(m.HomeTeam), text(" vs "), bold(m.AwayTeam)),
+			line(text(fmt.Sprintf("Current: %.2f - %.2f", m.HomeScore, m.AwayScore))),
+			line(text(fmt.Sprintf("Projected: %.2f - %.2f", m.HomeProjected, m.AwayProjected))),
+		}
+		if m.IsCompleted {
+			section = append(section, line(text("(Final)")))
+		}
+		doc = append(doc, section)
+	}
+
+	return doc
+}
+
+func layoutMatchups(r models.MatchupsReport) Document {
+	doc := Document{{line(text("🏈 "), bold(fmt.Sprintf("Week %d Matchups", r.Week)))}}
+
+	for _, m := range r.Matchups {
+		section := Section{
+			line(bold(m.HomeTeam), text(" vs "), bold(m.AwayTeam)),
+			line(text(fmt.Sprintf("Projected: %.2f - %.2f", m.HomeProjected, m.AwayProjected))),
+		}
+		if m.HomeScore > 0 || m.AwayScore > 0 {
+			current := fmt.Sprintf("Current: %.2f - %.2f", m.HomeScore, m.AwayScore)
+			if m.IsCompleted {
+				current += " (Final)"
+			}
+			section = append(section, line(text(current)))
+		}
+		doc = append(doc, section)
+	}
+
+	return doc
+}
+
+func layoutCloseGames(r models.CloseGamesReport) Document {
+	doc := Document{{line(text("🏈 "), bold("Monday Night Watch List"))}}
+
+	if len(r.Games) == 0 {
+		return append(doc, paragraph("No close games this week. All outcomes are likely decided."))
+	}
+
+	var games Section
+	for _, game := range r.Games {
+		games = append(games, line(text(fmt.Sprintf("%s %.2f - %.2f %s (Margin: %.2f)",
+			game.HomeTeam, game.HomeScore, game.AwayScore, game.AwayTeam, game.Margin))))
+	}
+
+	return append(doc, games)
+}
+
+func layoutWhoHas(r models.WhoHasResult) Document {
+	if !r.Found {
+		return Document{paragraph(fmt.Sprintf("🔍 No player found matching '%s'.", r.PlayerName))}
+	}
+
+	header := Section{
+		line(bold(r.PlayerName), text(fmt.Sprintf(" (%s - %s)", r.Position, r.ProTeam))),
+		line(text("━━━━━━━━━━━━━━━━")),
+	}
+
+	if r.TeamID != 0 {
+		header = append(header, line(bold(r.TeamName)))
+		if r.LineupSlot == "Bench" || r.LineupSlot == "IR" {
+			header = append(header, line(text(r.LineupSlot)))
+		} else {
+			header = append(header, line(text("Starting")))
+		}
+	} else {
+		header = append(header, line(text("Free Agent")))
+	}
+
+	var points string
+	if !r.IsProjected {
+		points = fmt.Sprintf("%.2f pts (Actual)", r.Points)
+	} else if r.Points > 0 {
+		points = fmt.Sprintf("%.2f pts (Projected)", r.Points)
+	} else {
+		points = "TBD pts"
+	}
+
+	return Document{header, {
+		line(text(points)),
+		line(text(fmt.Sprintf("%0.1f%% Rostered", r.PercentOwned))),
+	}}
+}
+
+func layoutPlayersToMonitor(r models.PlayersToMonitorReport) Document {
+	doc := Document{{line(text("🚑 "), bold(fmt.Sprintf("Week %d Players to Monitor", r.Week)))}}
+
+	if len(r.Teams) == 0 {
+		return append(doc, paragraph("No players to monitor at this time."))
+	}
+
+	for _, team := range r.Teams {
+		section := Section{line(bold(team.TeamName + ":"))}
+		for _, player := range team.Players {
+			section = append(section, line(text(fmt.Sprintf("  • %s %s - %s", player.Position, player.Name, player.InjuryStatus))))
+		}
+		doc = append(doc, section)
+	}
+
+	return doc
+}
+
+func layoutInjuries(r models.InjuryReport) Document {
+	doc := Document{{line(text("🚑 "), bold("Injury Updates"))}}
+
+	for _, c := range r.Changes {
+		p := c.Player
+		status := fmt.Sprintf("%s → %s", injuryStatusLabel(c.OldStatus), injuryStatusLabel(c.NewStatus))
+
+		if c.Urgent {
+			doc = append(doc, Section{
+				line(text("🚨 "), bold(fmt.Sprintf("STARTER: %s %s (%s)", p.Position, p.Name, p.ProTeam))),
+				line(bold(status)),
+				line(italic(fmt.Sprintf("%s - in the lineup and the game hasn't started", p.TeamName))),
+			})
+			continue
+		}
+
+		doc = append(doc, Section{
+			line(text(fmt.Sprintf("%s %s (%s): %s", p.Position, p.Name, p.ProTeam, status))),
+			line(text("   " + p.TeamName)),
+		})
+	}
+
+	return doc
+}
+
+func injuryStatusLabel(status string) string {
+	switch status {
+	case "INJURY_RESERVE":
+		return "IR"
+	case "":
+		return "ACTIVE"
+	default:
+		return status
+	}
+}
+
+func layoutTransactions(r models.TransactionsReport) Document {
+	title := "Transactions"
+	if r.Team != "" {
+		title = "Transactions: " + r.Team
+	}
+	doc := Document{{line(text("🔁 "), bold(title))}}
+
+	if len(r.Transactions) == 0 {
+		return append(doc, paragraph("No recent transactions."))
+	}
+
+	for _, t := range r.Transactions {
+		var section Section
+		switch t.Kind {
+		case models.TransactionTrade:
+			section = append(section, line(text("🤝 "), bold("Trade accepted")))
+			for _, item := range t.Items {
+				section = append(section, line(text(fmt.Sprintf("  • %s: %s → %s", transactionPlayer(item.Player), item.FromTeam, item.ToTeam))))
+			}
+		default:
+			for _, item := range t.Items {
+				switch item.Action {
+				case models.TransactionAdd:
+					verb := "added"
+					if t.Kind == models.TransactionWaiver {
+						verb = "claimed"
+					}
+					l := line(text("➕ "), bold(t.TeamName), text(fmt.Sprintf(" %s %s", verb, transactionPlayer(item.Player))))
+					if t.Kind == models.TransactionWaiver && t.BidAmount > 0 {
+						l = append(l, text(fmt.Sprintf(" for $%d", t.BidAmount)))
+					}
+					section = append(section, l)
+				case models.TransactionDrop:
+					section = append(section, line(text("➖ "), bold(t.TeamName), text(" dropped "+transactionPlayer(item.Player))))
+				}
+			}
+		}
+
+		if !t.Date.IsZero() {
+			section = append(section, line(italic(t.Date.Format("Mon Jan 2"))))
+		}
+		doc = append(doc, section)
+	}
+
+	return doc
+}
+
+func transactionPlayer(p models.PlayerCandidate) string {
+	if p.Position == "" {
+		return p.Name
+	}
+	return fmt.Sprintf("%s %s (%s)", p.Position, p.Name, p.ProTeam)
+}
+
+func layoutFreeAgents(r models.FreeAgentReport) Document {
+	title := fmt.Sprintf("Week %d Best Available", r.Week)
+	if r.Position != "" {
+		title = fmt.Sprintf("Week %d Best Available %s", r.Week, r.Position)
+	}
+	doc := Document{{line(text("📋 "), bold(title))}}
+
+	if len(r.Players) == 0 {
+		return append(doc, paragraph("No free agents found."))
+	}
+
+	for i, p := range r.Players {
+		name := fmt.Sprintf("%s %s (%s)", p.Position, p.Name, p.ProTeam)
+		if p.Status == "WA" {
+			name += " - waivers"
+		}
+		doc = append(doc, Section{
+			line(text(fmt.Sprintf("%d. ", i+1)), bold(name)),
+			line(text(fmt.Sprintf("   ROS: %.1f | Week %d: %.1f | Last week: %.1f", p.RestOfSeason, r.Week, p.WeekProjected, p.LastWeekPoints))),
+			line(text(fmt.Sprintf("   Owned: %.1f%% (%+.1f%%)", p.PercentOwned, p.PercentChange))),
+		})
+	}
+
+	return doc
+}
+
+func layoutWatchlist(r models.WatchlistReport) Document {
+	doc := Document{{line(text("👀 "), bold("Your Watchlist"))}}
+
+	if len(r.Players) == 0 {
+		return append(doc, paragraph("You aren't watching anyone. Use /watch <player> to start."))
+	}
+
+	var players Section
+	for _, p := range r.Players {
+		players = append(players, line(text("  • "+p.Name)))
+	}
+	return append(doc, players)
+}
+
+func layoutWatchEvents(r models.WatchEventsReport) Document {
+	doc := Document{{line(text("👀 "), bold("Watchlist Update"))}}
+
+	for _, e := range r.Events {
+		p := e.Player
+		name := fmt.Sprintf("%s %s (%s)", p.Position, p.Name, p.ProTeam)
+
+		switch e.Kind {
+		case models.WatchDropped:
+			where := "free agency"
+			if p.Availability == models.OnWaivers {
+				where = "waivers"
+			}
+			doc = append(doc, Section{
+				line(text("🚨 "), bold(name), text(" is available")),
+				line(text(fmt.Sprintf("   Dropped by %s to %s", e.FromTeam, where))),
+			})
+		case models.WatchOffIR:
+			doc = append(doc, Section{
+				line(text("💪 "), bold(name), text(" is off IR")),
+				line(text("   Now: " + injuryStatusLabel(e.NewStatus))),
+			})
+		case models.WatchInjury:
+			doc = append(doc, Section{
+				line(text("🚑 "), bold(name)),
+				line(text(fmt.Sprintf("   %s → %s", injuryStatusLabel(e.OldStatus), injuryStatusLabel(e.NewStatus)))),
+			})
+		}
+	}
+
+	return doc
+}
+
+func layoutPlayerGameLog(r models.PlayerGameLog) Document {
+	owner := "Free Agent"
+	if r.TeamName != "" {
+		owner = r.TeamName
+	}
+
+	doc := Document{
+		{line(text("👤 "), bold(fmt.Sprintf("%s %s (%s)", r.Position, r.Name, r.ProTeam)))},
+		{
+			line(text("Team: " + owner)),
+			line(text(fmt.Sprintf("Season: %.2f pts", r.SeasonPoints))),
+		},
+	}
+
+	weeks := Section{line(bold("Week  Actual  Projected"))}
+	for _, w := range r.Weeks {
+		actual := "-"
+		if w.Played {
+			actual = fmt.Sprintf("%.2f", w.Points)
+		}
+		weeks = append(weeks, line(text(fmt.Sprintf("%4d  %6s  %9.2f", w.Week, actual, w.Projected))))
+	}
+	doc = append(doc, weeks)
+
+	title := fmt.Sprintf("Week %d Stat Line", r.Week)
+	if r.StatLineProjected {
+		title = fmt.Sprintf("Week %d Projected Stat Line", r.Week)
+	}
+	stats := Section{line(bold(title))}
+	if len(r.StatLine) == 0 {
+		stats = append(stats, line(text("No stats yet.")))
+	}
+	for _, item := range r.StatLine {
+		stats = append(stats, line(text("  • "+statLineItem(item))))
+	}
+	if len(r.StatLine) > 0 {
+		stats = append(stats, line(bold(fmt.Sprintf("Total: %.2f pts", r.StatLinePoints))))
+		if !r.StatLineProjected && math.Abs(r.StatLinePoints-r.ESPNPoints) > 0.05 {
+			stats = append(stats, line(italic(fmt.Sprintf("⚠️ ESPN credited %.2f pts", r.ESPNPoints))))
+		}
+	}
+
+	return append(doc, stats)
+}
+
+func statLineItem(item models.StatLineItem) string {
+	switch {
+	case item.Value == 0:
+		return fmt.Sprintf("%s: %.2f pts", item.Category, item.Points)
+	case item.Points == 0:
+		return fmt.Sprintf("%s: %g", item.Category, math.Round(item.Value*10)/10)
+	default:
+		return fmt.Sprintf("%s: %g (%.2f pts)", item.Category, math.Round(item.Value*10)/10, item.Points)
+	}
+}
+
+func layoutJobs(r models.JobsReport) Document {
+	doc := Document{{line(text("⏰ "), bold("Scheduled Jobs"))}}
+	if r.Phase != "" {
+		doc[0] = append(doc[0], line(text("Season phase: "+phaseLabel(string(r.Phase)))))
+	}
+
+	if len(r.Jobs) == 0 {
+		return append(doc, paragraph("No jobs are scheduled."))
+	}
+
+	for _, job := range r.Jobs {
+		state := "next " + job.NextRun.Format("Mon Jan 2 15:04 MST")
+		switch {
+		case !job.Enabled:
+			state = "disabled"
+		case job.Paused:
+			state = "paused"
+		case !job.InPhase:
+			phases := make([]string, len(job.Phases))
+			for i, phase := range job.Phases {
+				phases[i] = phaseLabel(phase)
+			}
+			state = "only runs in " + strings.Join(phases, ", ")
+		case job.NextRun.IsZero():
+			state = "not scheduled"
+		}
+
+		schedule := job.Schedule
+		if job.Approve {
+			schedule += ", needs approval"
+		}
+
+		section := Section{
+			line(bold(job.Name), text(fmt.Sprintf(" (%s)", job.Report))),
+			line(text("   " + schedule)),
+			line(text("   "), italic(state)),
+		}
+		if run := job.LastRun; run.Status != "" {
+			last := fmt.Sprintf("last %s %s", run.Status, run.Started.Format("Mon Jan 2 15:04 MST"))
+			if run.Attempt > 1 {
+				last += fmt.Sprintf(" (attempt %d)", run.Attempt)
+			}
+			if run.Status == models.JobRunFailed {
+				last += ": " + run.Error
+			}
+			section = append(section, line(text("   "), italic(last)))
+		}
+		doc = append(doc, section)
+	}
+
+	return doc
+}
+
+func phaseLabel(phase string) string {
+	switch models.SeasonPhase(phase) {
+	case models.PhasePreseason:
+		return "preseason"
+	case models.PhaseRegularSeason:
+		return "regular season"
+	case models.PhasePlayoffs:
+		return "playoffs"
+	case models.PhaseOffseason:
+		return "offseason"
+	default:
+		return phase
+	}
+}
+
+func layoutFinalScores(r models.FinalScoreReport) Document {
+	doc := Document{{line(text("📊 "), bold("Final Scores:"))}}
+
+	var matchups Section
+	for _, m := range r.Matchups {
+		matchups = append(matchups, line(text(fmt.Sprintf("%s %.2f - %.2f %s", m.HomeTeam, m.HomeScore, m.AwayScore, m.AwayTeam))))
+	}
+	if len(matchups) > 0 {
+		doc = append(doc, matchups)
+	}
+
+	trophies := Section{line(text("🏆 "), bold("Trophies:"))}
+	for _, t := range r.Trophies {
+		switch t.Category {
+		case "High Score":
+			trophies = append(trophies, line(text(fmt.Sprintf("Highest Score: %s (%.2f)", t.Team, t.Value))))
+		case "Low Score":
+			trophies = append(trophies, line(text(fmt.Sprintf("Lowest Score: %s (%.2f)", t.Team, t.Value))))
+		case "Biggest Win":
+			trophies = append(trophies, line(text(fmt.Sprintf("Biggest Win: %s (Margin: %.2f)", t.Team, t.Value))))
+		case "Closest Win":
+			trophies = append(trophies, line(text(fmt.Sprintf("Closest Win: %s (Margin: %.2f)", t.Team, t.Value))))
+		}
+	}
+	doc = append(doc, trophies)
+
+	if len(r.Discrepancies) > 0 {
+		check := Section{line(text("⚠️ "), bold("Score Check:"))}
+		for _, d := range r.Discrepancies {
+			check = append(check, line(text(fmt.Sprintf("%s: ESPN %.2f, recalculated %.2f", d.TeamName, d.ESPN, d.Calculated))))
+		}
+		check = append(check, line(italic("Trophies may change if ESPN corrects these.")))
+		doc = append(doc, check)
+	}
+
+	return doc
+}
+
+func layoutStatCorrections(r models.StatCorrectionReport) Document {
+	doc := Document{{line(text("📝 "), bold(fmt.Sprintf("Week %d Stat Corrections", r.Week)))}}
+
+	if len(r.ScoreChanges) > 0 {
+		section := Section{line(bold("Updated Scores:"))}
+		for _, c := range r.ScoreChanges {
+			section = append(section, line(text(fmt.Sprintf("%s: %.2f → %.2f (%+.2f)", c.Team, c.Before, c.After, c.After-c.Before))))
+		}
+		doc = append(doc, section)
+	}
+
+	if len(r.TrophyChanges) > 0 {
+		section := Section{line(text("🏆 "), bold("Trophy Changes:"))}
+		for _, c := range r.TrophyChanges {
+			section = append(section, line(text(fmt.Sprintf("%s: %s (%.2f) → %s (%.2f)", c.After.Category, c.Before.Team, c.Before.Value, c.After.Team, c.After.Value))))
+		}
+		doc = append(doc, section)
+	}
+
+	if len(r.FlippedResults) > 0 {
+		section := Section{line(text("🔄 "), bold("Results Changed:"))}
+		for _, f := range r.FlippedResults {
+			section = append(section,
+				line(text(fmt.Sprintf("%s %.2f - %.2f %s", f.HomeTeam, f.HomeScore, f.AwayScore, f.AwayTeam))),
+				line(text("   Winner: "), italic(f.OldWinner), text(" → "), bold(f.NewWinner)),
+			)
+		}
+		doc = append(doc, section)
+	}
+
+	return doc
+}
+
+func layoutTeamRoster(r models.TeamRoster) Document {
+	starters := Section{line(bold("Starting Lineup:"))}
+	bench := Section{line(bold("Bench:"))}
+
+	for _, player := range r.Players {
+		if player.IsStarter {
+			starters = append(starters, rosterLine(player))
+			if len(player.Breakdown) > 0 {
+				starters = append(starters, breakdownLine(player.Breakdown))
+			}
+		} else {
+			bench = append(bench, rosterLine(player))
+		}
+	}
+
+	return Document{
+		{line(text("📋 "), bold(r.TeamName+"'s Roster"))},
+		starters,
+		bench,
+	}
+}
+
+// breakdownMax is how many scoring categories a roster line explains.
+const breakdownMax = 3
+
+// breakdownLine lists the categories that moved a player's score the most.
+func breakdownLine(breakdown []models.StatLineItem) Line {
+	items := slices.Clone(breakdown)
+	sort.SliceStable(items, func(i, j int) bool {
+		return math.Abs(items[i].Points) > math.Abs(items[j].Points)
+	})
+
+	parts := make([]string, 0, breakdownMax)
+	for _, item := range items[:min(len(items), breakdownMax)] {
+		parts = append(parts, fmt.Sprintf("%s %+.2f", item.Category, item.Points))
+	}
+	return line(text("      "), italic(strings.Join(parts, " · ")))
+}
+
+var injuryAbbreviations = map[string]string{
+	"QUESTIONABLE": "Q",
+	"DOUBTFUL":     "D",
+	"OUT":          "O",
+}
+
+func rosterLine(player models.RosterPlayer) Line {
+	pointsStr := player.PointsLabel
+	if pointsStr != "IR" && pointsStr != "BYE" {
+		pointsStr += " pts"
+	}
+
+	injuryStr := ""
+	if player.InjuryStatus != "" &&
+		player.InjuryStatus != "ACTIVE" &&
+		player.InjuryStatus != "INJURY_RESERVE" {
+		injuryStr = fmt.Sprintf(" (%s)", injuryAbbreviations[player.InjuryStatus])
+	}
+
+	return line(text(fmt.Sprintf("▫️ %s %s%s - %s", player.Position, player.Name, injuryStr, pointsStr)))
+}
+
+func layoutLiveScoreboard(r models.LiveScoreboard) Document {
+	doc := Document{{line(text("📺 "), bold(fmt.Sprintf("Week %d Live Scores", r.Week)))}}
+
+	for _, m := range r.Matchups {
+		doc = append(doc, Section{
+			line(bold(m.Home.TeamName), text(fmt.Sprintf(" %.2f - %.2f ", m.Home.Score, m.Away.Score)), bold(m.Away.TeamName)),
+			line(text(fmt.Sprintf("Projected: %.2f - %.2f", m.Home.Projected, m.Away.Projected))),
+			line(text(fmt.Sprintf("Yet to play: %d - %d · Playing: %d - %d",
+				m.Home.PlayersYetToPlay, m.Away.PlayersYetToPlay, m.Home.PlayersInProgress, m.Away.PlayersInProgress))),
+		})
+	}
+
+	var status string
+	switch {
+	case r.GamesInProgress:
+		status = "Live · updated " + r.UpdatedAt.Format("3:04 PM MST")
+	case r.GamesRemaining:
+		status = "No games in progress · updated " + r.UpdatedAt.Format("3:04 PM MST")
+	default:
+		status = "All games final"
+	}
+
+	return append(doc, Section{line(italic(status))})
+}
+
+func layoutLiveEvents(r models.LiveEventsReport) Document {
+	var doc Document
+
+	for _, e := range r.Events {
+		score := fmt.Sprintf(" (%.2f - %.2f)", e.TeamScore, e.OpponentScore)
+		switch e.Kind {
+		case models.EventLeadChange:
+			doc = append(doc, Section{line(text("🔄 "), bold(e.Team), text(" takes the lead over "), bold(e.Opponent), text(score))})
+		case models.EventLateLead:
+			doc = append(doc, Section{line(text("⏱️ "), bold(e.Team), text(" just took the lead over "), bold(e.Opponent),
+				text(fmt.Sprintf(" with %d left to play", e.PlayersLeft)), text(score))})
+		case models.EventComeback:
+			doc = append(doc, Section{line(text("🔥 "), bold(e.Team), text(fmt.Sprintf(" came back from %.2f down and leads ", e.Deficit)),
+				bold(e.Opponent), text(score))})
+		case models.EventProjectionFlip:
+			doc = append(doc, Section{line(text("📈 "), bold(e.Team), text(" is now projected to beat "), bold(e.Opponent),
+				text(fmt.Sprintf(" (%.2f - %.2f projected)", e.TeamProjected, e.OpponentProjected)))})
+		}
+	}
+
+	if len(doc) == 0 {
+		return Document{paragraph("No live events.")}
+	}
+	return doc
+}
+
+func layoutMatchupResult(r models.MatchupResultReport) Document {
+	score := fmt.Sprintf(" %.2f - %.2f ", r.WinnerScore, r.LoserScore)
+
+	if r.Stage == models.ResultOfficial {
+		if r.Tie {
+			return Document{{line(text("✅ Official: "), bold(r.Winner), text(score), bold(r.Loser), text(" ends in a tie"))}}
+		}
+		return Document{{line(text("✅ Official: "), bold(r.Winner), text(" def."), text(score), bold(r.Loser))}}
+	}
+
+	reason := fmt.Sprintf("%s has no players left to play.", r.Loser)
+	if r.LoserPlayersLeft > 0 {
+		reason = fmt.Sprintf("%s trails by %.2f with only %.2f projected from %d players left.",
+			r.Loser, r.WinnerScore-r.LoserScore, r.LoserRemaining, r.LoserPlayersLeft)
+	}
+
+	return Document{{
+		line(text("🏁 Final whistle: "), bold(r.Winner), text(" beats"), text(score), bold(r.Loser)),
+		line(text(reason)),
+	}}
+}
@@ -0,0 +1,174 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// Format selects how reports are rendered.
+type Format string
+
+const (
+	FormatMarkdownV2 Format = "markdownv2"
+	FormatHTML       Format = "html"
+	FormatPlain      Format = "plain"
+	FormatJSON       Format = "json"
+)
+
+// Renderer turns a report into text ready to be sent to Telegram.
+type Renderer interface {
+	Render(report models.Report) string
+	// Split breaks report into parts that each render to at most limit
+	// characters, splitting on section boundaries.
+	Split(report models.Report, limit int) []Part
+	// Page returns one page of report for paginated views, along with the
+	// total number of pages.
+	Page(report models.Report, page, pageLines int) (Part, int)
+	// ParseMode is the Telegram parse_mode for the rendered text, empty for none.
+	ParseMode() string
+}
+
+// New returns the renderer for format.
+func New(format Format) (Renderer, error) {
+	switch format {
+	case FormatMarkdownV2:
+		return MarkdownV2, nil
+	case FormatHTML:
+		return HTML, nil
+	case FormatPlain:
+		return Plain, nil
+	case FormatJSON:
+		return JSON, nil
+	default:
+		return nil, fmt.Errorf("unknown render format: %q", format)
+	}
+}
+
+var (
+	MarkdownV2 Renderer = markupRenderer{parseMode: "MarkdownV2", escape: escapeMarkdownV2, bold: "*", italic: "_"}
+	HTML       Renderer = markupRenderer{parseMode: "HTML", escape: html.EscapeString, bold: "b", italic: "i", tags: true}
+	Plain      Renderer = markupRenderer{escape: func(s string) string { return s }}
+	JSON       Renderer = jsonRenderer{}
+)
+
+type markupRenderer struct {
+	parseMode string
+	escape    func(string) string
+	bold      string
+	italic    string
+	// tags wraps styled spans in <x></x> instead of repeating the marker.
+	tags bool
+}
+
+func (r markupRenderer) ParseMode() string {
+	return r.parseMode
+}
+
+func (r markupRenderer) Render(report models.Report) string {
+	return r.document(Layout(report))
+}
+
+func (r markupRenderer) document(doc Document) string {
+	sections := make([]string, len(doc))
+	for i, section := range doc {
+		sections[i] = r.section(section)
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+func (r markupRenderer) section(section Section) string {
+	lines := make([]string, len(section))
+	for i, l := range section {
+		lines[i] = r.line(l)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r markupRenderer) line(l Line) string {
+	var sb strings.Builder
+	for _, span := range l {
+		sb.WriteString(r.span(span))
+	}
+	return sb.String()
+}
+
+func (r markupRenderer) span(span Span) string {
+	escaped := r.escape(span.Text)
+
+	var marker string
+	switch span.Style {
+	case Bold:
+		marker = r.bold
+	case Italic:
+		marker = r.italic
+	}
+
+	if marker == "" || escaped == "" {
+		return escaped
+	}
+	if r.tags {
+		return fmt.Sprintf("<%s>%s</%s>", marker, escaped, marker)
+	}
+	return marker + escaped + marker
+}
+
+// markdownV2Special are the characters Telegram requires to be escaped
+// anywhere in MarkdownV2 text.
+const markdownV2Special = "_*[]()~`>#+-=|{}.!\\"
+
+func escapeMarkdownV2(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(markdownV2Special, r) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) ParseMode() string {
+	return ""
+}
+
+func (jsonRenderer) Render(report models.Report) string {
+	if part, ok := report.(Part); ok {
+		return Plain.Render(part)
+	}
+
+	payload := struct {
+		Type   string        `json:"type"`
+		Report models.Report `json:"report"`
+	}{
+		Type:   report.ReportType(),
+		Report: report,
+	}
+
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"type": %q, "error": %q}`, report.ReportType(), err.Error())
+	}
+	return string(data)
+}
+
+func (r jsonRenderer) Split(report models.Report, limit int) []Part {
+	chunks := splitText(r.Render(report), limit)
+
+	parts := make([]Part, len(chunks))
+	for i, chunk := range chunks {
+		parts[i] = Part{Type: report.ReportType(), Doc: Document{{line(text(chunk))}}}
+	}
+	return parts
+}
+
+func (r jsonRenderer) Page(report models.Report, page, _ int) (Part, int) {
+	parts := r.Split(report, MessageLimit)
+	page = max(0, min(page, len(parts)-1))
+	return parts[page], len(parts)
+}
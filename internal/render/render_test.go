@@ -0,0 +1,122 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEscapeMarkdownV2(t *testing.T) {
+	// Every character Telegram reserves in MarkdownV2.
+	for _, c := range "_*[]()~`>#+-=|{}.!\\" {
+		t.Run(string(c), func(t *testing.T) {
+			got := escapeMarkdownV2("a" + string(c) + "b")
+			if want := `a\` + string(c) + "b"; got != want {
+				t.Errorf("escapeMarkdownV2 = %q, want %q", got, want)
+			}
+		})
+	}
+
+	tests := []struct {
+		in, want string
+	}{
+		{in: "", want: ""},
+		{in: "Coach Dad", want: "Coach Dad"},
+		{in: "1. J. Allen (QB) - 25.5 pts!", want: `1\. J\. Allen \(QB\) \- 25\.5 pts\!`},
+		{in: "🏈 Week 3: 101.2-99.8", want: `🏈 Week 3: 101\.2\-99\.8`},
+		{in: `\\`, want: `\\\\`},
+		{in: "<b>&amp;</b>", want: `<b\>&amp;</b\>`},
+	}
+	for _, tt := range tests {
+		if got := escapeMarkdownV2(tt.in); got != tt.want {
+			t.Errorf("escapeMarkdownV2(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRenderSpan(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer Renderer
+		span     Span
+		want     string
+	}{
+		{name: "markdown bold", renderer: MarkdownV2, span: bold("Week 1."), want: `*Week 1\.*`},
+		{name: "markdown italic", renderer: MarkdownV2, span: italic("(proj)"), want: `_\(proj\)_`},
+		{name: "markdown marker in text", renderer: MarkdownV2, span: bold("a*b_c"), want: `*a\*b\_c*`},
+		{name: "markdown empty styled span", renderer: MarkdownV2, span: bold(""), want: ""},
+		{name: "html bold", renderer: HTML, span: bold("A & B"), want: "<b>A &amp; B</b>"},
+		{name: "html italic", renderer: HTML, span: italic("<3"), want: "<i>&lt;3</i>"},
+		{name: "plain drops styles", renderer: Plain, span: bold("1. *Top*"), want: "1. *Top*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.renderer.(markupRenderer).span(tt.span)
+			if got != tt.want {
+				t.Errorf("span = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderDocument(t *testing.T) {
+	doc := Document{
+		{line(bold("Standings"))},
+		{line(text("1. A")), line(text("2. B"), italic(" (tie)"))},
+	}
+	part := Part{Type: "test", Doc: doc}
+
+	tests := []struct {
+		renderer Renderer
+		want     string
+	}{
+		{renderer: MarkdownV2, want: "*Standings*\n\n1\\. A\n2\\. B_ \\(tie\\)_"},
+		{renderer: HTML, want: "<b>Standings</b>\n\n1. A\n2. B<i> (tie)</i>"},
+		{renderer: Plain, want: "Standings\n\n1. A\n2. B (tie)"},
+	}
+	for _, tt := range tests {
+		if got := tt.renderer.Render(part); got != tt.want {
+			t.Errorf("%s: Render = %q, want %q", formatName(tt.renderer), got, tt.want)
+		}
+	}
+}
+
+func formatName(r Renderer) string {
+	if name := r.ParseMode(); name != "" {
+		return name
+	}
+	return "plain"
+}
+
+// checkMarkdownV2 fails if s isn't well-formed MarkdownV2 as the renderer
+// writes it: every reserved character escaped except the bold and italic
+// markers, markers paired up, and no escape left dangling at the end.
+func checkMarkdownV2(t *testing.T, s string) {
+	t.Helper()
+
+	open := map[rune]bool{}
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			if !strings.ContainsRune(markdownV2Special, r) {
+				t.Errorf("%q escapes %q, which isn't reserved", s, r)
+			}
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '*' || r == '_':
+			open[r] = !open[r]
+		case strings.ContainsRune(markdownV2Special, r):
+			t.Errorf("%q has an unescaped %q", s, r)
+		}
+	}
+	if escaped {
+		t.Errorf("%q ends inside an escape sequence", s)
+	}
+	for marker, isOpen := range open {
+		if isOpen {
+			t.Errorf("%q leaves a %q entity open", s, marker)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package render
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// MessageLimit is the maximum length of a Telegram message, in UTF-16 code
+// units.
+const MessageLimit = 4096
+
+// budget bounds the size of one part of a split document. Zero means no limit.
+type budget struct {
+	chars int
+	lines int
+}
+
+func (b budget) fits(chars, lines int) bool {
+	return (b.chars == 0 || chars <= b.chars) && (b.lines == 0 || lines <= b.lines)
+}
+
+// split groups doc into parts that each fit within b. Parts break between
+// sections where possible, then between lines, and as a last resort between
+// spans, so formatting entities are never cut in half.
+func (r markupRenderer) split(doc Document, b budget) []Document {
+	var parts []Document
+	var current Document
+	var chars, lines int
+
+	for _, section := range doc {
+		for _, piece := range r.fitSection(section, b) {
+			pieceChars := textLen(r.section(piece))
+			if len(current) > 0 {
+				pieceChars += len("\n\n")
+			}
+
+			if len(current) > 0 && !b.fits(chars+pieceChars, lines+len(piece)) {
+				parts = append(parts, current)
+				current, chars, lines = nil, 0, 0
+				pieceChars -= len("\n\n")
+			}
+
+			current = append(current, piece)
+			chars += pieceChars
+			lines += len(piece)
+		}
+	}
+
+	if len(current) > 0 {
+		parts = append(parts, current)
+	}
+	return parts
+}
+
+// fitSection breaks a section that is too large on its own into smaller ones.
+func (r markupRenderer) fitSection(section Section, b budget) []Section {
+	if b.fits(textLen(r.section(section)), len(section)) {
+		return []Section{section}
+	}
+
+	var pieces []Section
+	var current Section
+	var chars int
+
+	for _, l := range section {
+		for _, piece := range r.fitLine(l, b) {
+			lineChars := textLen(r.line(piece))
+			if len(current) > 0 {
+				lineChars++
+			}
+
+			if len(current) > 0 && !b.fits(chars+lineChars, len(current)+1) {
+				pieces = append(pieces, current)
+				current, chars = nil, 0
+				lineChars--
+			}
+
+			current = append(current, piece)
+			chars += lineChars
+		}
+	}
+
+	if len(current) > 0 {
+		pieces = append(pieces, current)
+	}
+	return pieces
+}
+
+// fitLine breaks a line longer than the budget into several lines. Each span
+// is split on its raw text and styled again, so no entity is left open.
+func (r markupRenderer) fitLine(l Line, b budget) []Line {
+	if b.chars == 0 || textLen(r.line(l)) <= b.chars {
+		return []Line{l}
+	}
+
+	var lines []Line
+	var current Line
+	var chars int
+
+	for _, span := range l {
+		for _, piece := range r.fitSpan(span, b.chars) {
+			spanChars := textLen(r.span(piece))
+			if len(current) > 0 && chars+spanChars > b.chars {
+				lines = append(lines, current)
+				current, chars = nil, 0
+			}
+			current = append(current, piece)
+			chars += spanChars
+		}
+	}
+
+	if len(current) > 0 {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+func (r markupRenderer) fitSpan(span Span, limit int) []Span {
+	var spans []Span
+	remaining := []rune(span.Text)
+
+	fits := func(n int) bool {
+		return textLen(r.span(Span{Text: string(remaining[:n]), Style: span.Style})) <= limit
+	}
+	for len(remaining) > 0 {
+		// The rendered length only grows with the text, so search for the
+		// longest piece that fits, taking at least one character.
+		n := sort.Search(len(remaining), func(n int) bool { return !fits(n + 1) })
+		n = max(n, 1)
+		spans = append(spans, Span{Text: string(remaining[:n]), Style: span.Style})
+		remaining = remaining[n:]
+	}
+
+	return spans
+}
+
+// Part is a piece of a report produced by Split or Page. It renders like the
+// report it came from in every format, so a part that Telegram rejects can be
+// sent again as plain text without re-splitting.
+type Part struct {
+	Type string
+	Doc  Document
+}
+
+func (p Part) ReportType() string {
+	return p.Type
+}
+
+func (r markupRenderer) Split(report models.Report, limit int) []Part {
+	docs := r.split(Layout(report), budget{chars: limit})
+
+	parts := make([]Part, len(docs))
+	for i, doc := range docs {
+		parts[i] = Part{Type: report.ReportType(), Doc: doc}
+	}
+	return parts
+}
+
+// Page returns page (zero-based) of report, where every page repeats the
+// report's heading and holds at most pageLines further lines, along with the
+// total number of pages.
+func (r markupRenderer) Page(report models.Report, page, pageLines int) (Part, int) {
+	doc := Layout(report)
+	if len(doc) <= 1 {
+		return Part{Type: report.ReportType(), Doc: doc}, 1
+	}
+
+	header := doc[0]
+	b := budget{
+		chars: MessageLimit - textLen(r.section(header)) - len("\n\n"),
+		lines: pageLines,
+	}
+	pages := r.split(doc[1:], b)
+
+	page = max(0, min(page, len(pages)-1))
+	return Part{Type: report.ReportType(), Doc: append(Document{header}, pages[page]...)}, len(pages)
+}
+
+// textLen is the length of s as Telegram counts it, in UTF-16 code units,
+// so emoji and other characters outside the Basic Multilingual Plane count
+// as two.
+func textLen(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// textCut returns the byte length of the longest prefix of s that is at most
+// limit long, but at least one character.
+func textCut(s string, limit int) int {
+	n := 0
+	for i, r := range s {
+		n += utf16.RuneLen(r)
+		if n > limit {
+			if i == 0 {
+				return utf8.RuneLen(r)
+			}
+			return i
+		}
+	}
+	return len(s)
+}
+
+// splitText breaks unformatted text into chunks of at most limit characters,
+// preferring to break after a newline.
+func splitText(s string, limit int) []string {
+	var chunks []string
+	for textLen(s) > limit {
+		cut := textCut(s, limit)
+		if i := strings.LastIndex(s[:cut], "\n"); i > 0 {
+			cut = i + 1
+		}
+		chunks = append(chunks, s[:cut])
+		s = s[cut:]
+	}
+	return append(chunks, s)
+}
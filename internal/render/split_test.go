@@ -0,0 +1,331 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// spanText is every span's text in doc, in order, so a split can be checked
+// for losing or repeating content.
+func spanText(doc Document) string {
+	var sb strings.Builder
+	for _, section := range doc {
+		for _, l := range section {
+			for _, span := range l {
+				sb.WriteString(span.Text)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// standings is a document of n sections, each a bold heading and a few
+// lines full of characters MarkdownV2 has to escape.
+func standings(n int) Document {
+	var doc Document
+	for i := range n {
+		doc = append(doc, Section{
+			line(bold(fmt.Sprintf("%d. Team #%d (8-2)", i+1, i))),
+			line(text("PF: 1,234.56 - PA: 1,100.00!"), italic(" [clinched]")),
+			line(text("Streak: W3 | Next: Team_" + fmt.Sprint(i+1))),
+		})
+	}
+	return doc
+}
+
+func TestSplitKeepsEntitiesWhole(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   Document
+		limit int
+	}{
+		{name: "fits in one part", doc: standings(3), limit: MessageLimit},
+		{name: "breaks between sections", doc: standings(40), limit: 400},
+		{name: "breaks inside sections", doc: standings(5), limit: 60},
+		{name: "breaks inside spans", doc: Document{{line(bold(strings.Repeat("a.b_c*", 30)))}}, limit: 50},
+		{name: "escapes everywhere", doc: Document{{line(italic(strings.Repeat(".", 100)))}}, limit: 21},
+	}
+
+	for _, tt := range tests {
+		for _, renderer := range []Renderer{MarkdownV2, HTML, Plain} {
+			t.Run(tt.name+"/"+formatName(renderer), func(t *testing.T) {
+				parts := renderer.Split(Part{Type: "test", Doc: tt.doc}, tt.limit)
+
+				var got strings.Builder
+				for _, part := range parts {
+					rendered := renderer.Render(part)
+					if n := textLen(rendered); n > tt.limit {
+						t.Errorf("part is %d long, limit %d: %q", n, tt.limit, rendered)
+					}
+					if renderer.ParseMode() == "MarkdownV2" {
+						checkMarkdownV2(t, rendered)
+					}
+					if renderer.ParseMode() == "HTML" && strings.Count(rendered, "<") != strings.Count(rendered, ">") {
+						t.Errorf("part cuts an HTML tag: %q", rendered)
+					}
+					got.WriteString(spanText(part.Doc))
+				}
+				if want := spanText(tt.doc); got.String() != want {
+					t.Errorf("parts hold %q, want %q", got.String(), want)
+				}
+			})
+		}
+	}
+}
+
+func TestFitSpan(t *testing.T) {
+	r := MarkdownV2.(markupRenderer)
+
+	tests := []struct {
+		name  string
+		span  Span
+		limit int
+		parts int
+	}{
+		{name: "fits", span: text("Coach Dad"), limit: 20, parts: 1},
+		{name: "plain text", span: text(strings.Repeat("x", 100)), limit: 40, parts: 3},
+		{name: "every character escaped", span: bold(strings.Repeat("!", 50)), limit: 30, parts: 4},
+		{name: "tiny limit", span: italic("a.b"), limit: 4, parts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pieces := r.fitSpan(tt.span, tt.limit)
+			if len(pieces) != tt.parts {
+				t.Errorf("got %d pieces, want %d", len(pieces), tt.parts)
+			}
+
+			var joined strings.Builder
+			for _, piece := range pieces {
+				if piece.Style != tt.span.Style {
+					t.Errorf("piece lost its style: %+v", piece)
+				}
+				rendered := r.span(piece)
+				if textLen(rendered) > tt.limit {
+					t.Errorf("piece %q is longer than %d", rendered, tt.limit)
+				}
+				checkMarkdownV2(t, rendered)
+				joined.WriteString(piece.Text)
+			}
+			if joined.String() != tt.span.Text {
+				t.Errorf("pieces hold %q, want %q", joined.String(), tt.span.Text)
+			}
+		})
+	}
+}
+
+func TestFitLine(t *testing.T) {
+	r := MarkdownV2.(markupRenderer)
+	long := line(bold("Josh Allen (QB)"), text(" - "), italic(strings.Repeat("25.5 ", 20)))
+
+	tests := []struct {
+		name  string
+		line  Line
+		chars int
+		lines int
+	}{
+		{name: "no limit", line: long, chars: 0, lines: 1},
+		{name: "fits", line: long, chars: 500, lines: 1},
+		{name: "too long", line: long, chars: 40, lines: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := r.fitLine(tt.line, budget{chars: tt.chars})
+			if len(lines) != tt.lines {
+				t.Errorf("got %d lines, want %d", len(lines), tt.lines)
+			}
+			for _, l := range lines {
+				rendered := r.line(l)
+				if tt.chars > 0 && textLen(rendered) > tt.chars {
+					t.Errorf("line %q is longer than %d", rendered, tt.chars)
+				}
+				checkMarkdownV2(t, rendered)
+			}
+			if got, want := spanText(Document{lines}), spanText(Document{{tt.line}}); got != want {
+				t.Errorf("lines hold %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestFitSection(t *testing.T) {
+	r := MarkdownV2.(markupRenderer)
+	section := Section{
+		line(bold("Waiver Wire")),
+		line(text("1. Jaylen Warren (RB) - 8.4 pts")),
+		line(text(strings.Repeat("A very long note. ", 10))),
+		line(text("2. Rashid Shaheed (WR) - 7.9 pts")),
+	}
+
+	tests := []struct {
+		name   string
+		b      budget
+		pieces int
+	}{
+		{name: "fits", b: budget{chars: 1000}, pieces: 1},
+		{name: "too many lines", b: budget{lines: 2}, pieces: 2},
+		{name: "single line too long", b: budget{chars: 60}, pieces: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pieces := r.fitSection(section, tt.b)
+			if len(pieces) != tt.pieces {
+				t.Errorf("got %d pieces, want %d", len(pieces), tt.pieces)
+			}
+			for _, piece := range pieces {
+				if !tt.b.fits(textLen(r.section(piece)), len(piece)) {
+					t.Errorf("piece %q doesn't fit %+v", r.section(piece), tt.b)
+				}
+			}
+			if got, want := spanText(Document(pieces)), spanText(Document{section}); got != want {
+				t.Errorf("pieces hold %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestPage(t *testing.T) {
+	heading := Section{line(bold("Transactions"))}
+	body := func(lines int) Section {
+		var section Section
+		for i := range lines {
+			section = append(section, line(text(fmt.Sprintf("Move %d", i+1))))
+		}
+		return section
+	}
+
+	tests := []struct {
+		name      string
+		doc       Document
+		page      int
+		pageLines int
+		wantPages int
+		wantFirst string
+	}{
+		{name: "heading only", doc: Document{heading}, page: 0, pageLines: 10, wantPages: 1, wantFirst: "Transactions"},
+		{name: "one page", doc: Document{heading, body(10)}, page: 0, pageLines: 10, wantPages: 1, wantFirst: "Move 1"},
+		{name: "first of three", doc: Document{heading, body(25)}, page: 0, pageLines: 10, wantPages: 3, wantFirst: "Move 1"},
+		{name: "second of three", doc: Document{heading, body(25)}, page: 1, pageLines: 10, wantPages: 3, wantFirst: "Move 11"},
+		{name: "last of three", doc: Document{heading, body(25)}, page: 2, pageLines: 10, wantPages: 3, wantFirst: "Move 21"},
+		{name: "past the end", doc: Document{heading, body(25)}, page: 7, pageLines: 10, wantPages: 3, wantFirst: "Move 21"},
+		{name: "negative", doc: Document{heading, body(25)}, page: -1, pageLines: 10, wantPages: 3, wantFirst: "Move 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			part, pages := MarkdownV2.Page(Part{Type: "test", Doc: tt.doc}, tt.page, tt.pageLines)
+			if pages != tt.wantPages {
+				t.Errorf("pages = %d, want %d", pages, tt.wantPages)
+			}
+			if got := spanText(Document{part.Doc[0]}); got != "Transactions" {
+				t.Errorf("page starts with %q, want the heading", got)
+			}
+
+			first := part.Doc[0][0][0].Text
+			if len(part.Doc) > 1 {
+				first = part.Doc[1][0][0].Text
+			}
+			if first != tt.wantFirst {
+				t.Errorf("first line = %q, want %q", first, tt.wantFirst)
+			}
+
+			lines := 0
+			for _, section := range part.Doc[1:] {
+				lines += len(section)
+			}
+			if lines > tt.pageLines {
+				t.Errorf("page has %d lines, want at most %d", lines, tt.pageLines)
+			}
+		})
+	}
+}
+
+func TestTextLen(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		units int
+		runes int
+		bytes int
+	}{
+		{name: "ascii", s: "Coach Dad", units: 9, runes: 9, bytes: 9},
+		{name: "accented", s: "Montaño", units: 7, runes: 7, bytes: 8},
+		{name: "basic plane symbol", s: "✅ ok", units: 4, runes: 4, bytes: 6},
+		{name: "emoji", s: "🏈", units: 2, runes: 1, bytes: 4},
+		{name: "emoji with text", s: "🏆 Week 3", units: 9, runes: 8, bytes: 11},
+		{name: "flag", s: "🇺🇸", units: 4, runes: 2, bytes: 8},
+		{name: "math letter", s: "𝔸", units: 2, runes: 1, bytes: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := textLen(tt.s); got != tt.units {
+				t.Errorf("textLen = %d, want %d", got, tt.units)
+			}
+			if got := utf8.RuneCountInString(tt.s); got != tt.runes {
+				t.Errorf("runes = %d, want %d", got, tt.runes)
+			}
+			if len(tt.s) != tt.bytes {
+				t.Errorf("bytes = %d, want %d", len(tt.s), tt.bytes)
+			}
+		})
+	}
+}
+
+func TestSplitAtUTF16Limit(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+	}{
+		// Fits in 4096 runes but not in 4096 UTF-16 units.
+		{name: "emoji after ascii", s: strings.Repeat("x", 4000) + strings.Repeat("🏈", 90)},
+		{name: "emoji only", s: strings.Repeat("🏆", 3000)},
+		// An emoji straddling the boundary mustn't be cut in half.
+		{name: "emoji on the boundary", s: strings.Repeat("x", 4095) + "🏈" + "tail"},
+		{name: "mixed planes", s: strings.Repeat("✅𝔸é ", 1200)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := splitText(tt.s, MessageLimit)
+			if len(chunks) < 2 {
+				t.Errorf("got %d chunk, want the text split", len(chunks))
+			}
+			for _, chunk := range chunks {
+				if n := textLen(chunk); n > MessageLimit {
+					t.Errorf("chunk is %d UTF-16 units, limit %d", n, MessageLimit)
+				}
+				if !utf8.ValidString(chunk) {
+					t.Errorf("chunk cuts a character in half")
+				}
+			}
+			if got := strings.Join(chunks, ""); got != tt.s {
+				t.Errorf("chunks don't add up to the text")
+			}
+
+			parts := Plain.Split(Part{Type: "test", Doc: Document{{line(text(tt.s))}}}, MessageLimit)
+			for _, part := range parts {
+				if n := textLen(Plain.Render(part)); n > MessageLimit {
+					t.Errorf("part is %d UTF-16 units, limit %d", n, MessageLimit)
+				}
+			}
+		})
+	}
+
+	// Counting runes lets through a message Telegram rejects, and counting
+	// bytes cuts it well short of the limit.
+	s := strings.Repeat("x", 4000) + strings.Repeat("🏈", 90)
+	if runes := utf8.RuneCountInString(s); runes > MessageLimit {
+		t.Fatalf("fixture is %d runes, want it to fit by rune count", runes)
+	}
+	if units := textLen(s); units <= MessageLimit {
+		t.Errorf("textLen = %d, want it over the limit", units)
+	}
+	if bytes := len(s); bytes <= textLen(s) {
+		t.Errorf("bytes = %d, want more than the UTF-16 length", bytes)
+	}
+}
@@ -1,18 +1,123 @@
 package memory
 
 import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
 	"sync"
+	"time"
 
+	"github.com/omarshaarawi/coachbot/internal/health"
 	"github.com/omarshaarawi/coachbot/internal/models"
 )
 
 type Repository struct {
-	metadata *models.LeagueMetadata
-	mu       sync.RWMutex
+	metadata    *models.LeagueMetadata
+	matchups    map[int]models.MatchupSnapshot
+	results     map[int]models.MatchupResultState
+	resultStore *store
+	announced   map[int]models.FinalScoreReport
+	scoreStore  *store
+	injuries    map[int]string
+	seenTx      map[string]bool
+	seenTxStore *store
+	watchlists  map[int64]map[int]models.WatchedPlayer
+	watched     map[int]models.PlayerState
+	watchStore  *store
+	pausedJobs  map[string]bool
+	jobRuns     []models.JobRun
+	jobStore    *store
+	outbound    []models.OutboundMessage
+	outStore    *store
+	alertChats  map[int64]bool
+	alertStore  *store
+	alertSaved  bool
+	mu          sync.RWMutex
 }
 
+// maxJobRuns is how many job runs are kept, and maxOutbound how many
+// scheduled posts.
+const (
+	maxJobRuns  = 1000
+	maxOutbound = 500
+)
+
 func NewRepository() *Repository {
-	return &Repository{}
+	r := &Repository{
+		matchups:   make(map[int]models.MatchupSnapshot),
+		results:    make(map[int]models.MatchupResultState),
+		announced:  make(map[int]models.FinalScoreReport),
+		alertChats: make(map[int64]bool),
+		watchlists: make(map[int64]map[int]models.WatchedPlayer),
+		watched:    make(map[int]models.PlayerState),
+		pausedJobs: make(map[string]bool),
+	}
+
+	r.resultStore = newStore("matchup results",
+		func() map[int]models.MatchupResultState { return r.results },
+		func(results map[int]models.MatchupResultState) {
+			if results == nil {
+				results = make(map[int]models.MatchupResultState)
+			}
+			r.results = results
+		})
+	r.scoreStore = newStore("announced scores",
+		func() map[int]models.FinalScoreReport { return r.announced },
+		func(announced map[int]models.FinalScoreReport) {
+			if announced == nil {
+				announced = make(map[int]models.FinalScoreReport)
+			}
+			r.announced = announced
+		})
+	r.seenTxStore = newStore("seen transactions",
+		func() []string { return slices.Sorted(maps.Keys(r.seenTx)) },
+		func(ids []string) {
+			// Without a saved file, the next poll starts afresh.
+			if ids == nil {
+				return
+			}
+			r.seenTx = make(map[string]bool, len(ids))
+			for _, id := range ids {
+				r.seenTx[id] = true
+			}
+		})
+	r.watchStore = newStore("watchlists",
+		func() watchState { return watchState{Watchlists: r.watchlists, Players: r.watched} },
+		func(state watchState) {
+			r.watchlists = make(map[int64]map[int]models.WatchedPlayer)
+			maps.Copy(r.watchlists, state.Watchlists)
+			r.watched = make(map[int]models.PlayerState)
+			maps.Copy(r.watched, state.Players)
+		})
+	r.jobStore = newStore("job state",
+		func() jobState { return jobState{Paused: slices.Sorted(maps.Keys(r.pausedJobs)), Runs: r.jobRuns} },
+		func(state jobState) {
+			r.pausedJobs = make(map[string]bool)
+			for _, name := range state.Paused {
+				r.pausedJobs[name] = true
+			}
+			r.jobRuns = state.Runs
+		})
+	r.outStore = newStore("outbound messages",
+		func() []models.OutboundMessage { return r.outbound },
+		func(outbound []models.OutboundMessage) { r.outbound = outbound })
+	r.alertStore = newStore("alert chats",
+		func() []int64 { return slices.Sorted(maps.Keys(r.alertChats)) },
+		func(chats []int64) {
+			if chats == nil {
+				return
+			}
+			r.alertChats = make(map[int64]bool, len(chats))
+			for _, chatID := range chats {
+				r.alertChats[chatID] = true
+			}
+			r.alertSaved = true
+		})
+	return r
 }
 
 func (r *Repository) SaveMetadata(metadata *models.LeagueMetadata) {
@@ -26,3 +131,551 @@ func (r *Repository) GetMetadata() *models.LeagueMetadata {
 	defer r.mu.RUnlock()
 	return r.metadata
 }
+
+// SaveMatchupSnapshot stores the latest live poll of a matchup.
+func (r *Repository) SaveMatchupSnapshot(snapshot models.MatchupSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.matchups[snapshot.MatchID] = snapshot
+}
+
+// GetMatchupSnapshot returns the previous live poll of a matchup, if any.
+func (r *Repository) GetMatchupSnapshot(matchID int) (models.MatchupSnapshot, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	snapshot, ok := r.matchups[matchID]
+	return snapshot, ok
+}
+
+// PersistResults loads the matchup result states saved at path and saves
+// them there whenever they change, so results aren't announced twice or lost
+// across restarts.
+func (r *Repository) PersistResults(path string) error {
+	return r.persist(r.resultStore, path)
+}
+
+// ReloadResults replaces the matchup result states with the ones saved on
+// disk.
+func (r *Repository) ReloadResults() error {
+	return r.reload(r.resultStore)
+}
+
+func (r *Repository) SaveMatchupResultState(state models.MatchupResultState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, ok := r.results[state.MatchID]; ok && current == state {
+		return
+	}
+	r.results[state.MatchID] = state
+	r.resultStore.save()
+}
+
+// PruneMatchupResults forgets the result states of matchups outside weeks
+// from through to, e.g. ones that ESPN never marked completed or left over
+// from last season.
+func (r *Repository) PruneMatchupResults(from, to int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pruned := false
+	for matchID, state := range r.results {
+		if state.Week < from || state.Week > to {
+			delete(r.results, matchID)
+			pruned = true
+		}
+	}
+	if pruned {
+		r.resultStore.save()
+	}
+}
+
+func (r *Repository) GetMatchupResultState(matchID int) (models.MatchupResultState, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	state, ok := r.results[matchID]
+	return state, ok
+}
+
+// GetPendingResults returns tracked matchups whose official result has not
+// been announced yet.
+func (r *Repository) GetPendingResults() []models.MatchupResultState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var pending []models.MatchupResultState
+	for _, state := range r.results {
+		if state.Tracked && !state.Official {
+			pending = append(pending, state)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].MatchID < pending[j].MatchID })
+	return pending
+}
+
+// PersistAnnouncedScores loads the announced final scores saved at path and
+// saves them there whenever they change, so stat corrections are still
+// detected after a restart.
+func (r *Repository) PersistAnnouncedScores(path string) error {
+	return r.persist(r.scoreStore, path)
+}
+
+// ReloadAnnouncedScores replaces the announced final scores with the ones
+// saved on disk.
+func (r *Repository) ReloadAnnouncedScores() error {
+	return r.reload(r.scoreStore)
+}
+
+// SaveAnnouncedScores stores the final scores posted for report.Week.
+func (r *Repository) SaveAnnouncedScores(report models.FinalScoreReport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.announced[report.Week] = report
+	r.scoreStore.save()
+}
+
+// GetAnnouncedScores returns the final scores announced for a week.
+func (r *Repository) GetAnnouncedScores(week int) (models.FinalScoreReport, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	report, ok := r.announced[week]
+	return report, ok
+}
+
+// GetLatestAnnouncedScores returns the final scores of the most recent
+// announced week.
+func (r *Repository) GetLatestAnnouncedScores() (models.FinalScoreReport, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest models.FinalScoreReport
+	found := false
+	for week, report := range r.announced {
+		if !found || week > latest.Week {
+			latest = report
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// GetInjuryStatuses returns the last injury status snapshot keyed by player
+// ID. It reports false if no snapshot has been saved yet.
+func (r *Repository) GetInjuryStatuses() (map[int]string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.injuries == nil {
+		return nil, false
+	}
+	return maps.Clone(r.injuries), true
+}
+
+// SaveInjuryStatuses replaces the injury status snapshot.
+func (r *Repository) SaveInjuryStatuses(statuses map[int]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.injuries = maps.Clone(statuses)
+}
+
+// GetSeenTransactions returns the transaction IDs seen by the last poll. It
+// reports false if no poll has been saved yet.
+func (r *Repository) GetSeenTransactions() (map[string]bool, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.seenTx == nil {
+		return nil, false
+	}
+	return maps.Clone(r.seenTx), true
+}
+
+// PersistSeenTransactions loads the transaction IDs saved at path and saves
+// them there whenever they change, so transactions made while the bot was
+// down are still posted.
+func (r *Repository) PersistSeenTransactions(path string) error {
+	return r.persist(r.seenTxStore, path)
+}
+
+// ReloadSeenTransactions replaces the seen transaction IDs with the ones
+// saved on disk. Without a saved file, the next poll starts afresh.
+func (r *Repository) ReloadSeenTransactions() error {
+	return r.reload(r.seenTxStore)
+}
+
+// SaveSeenTransactions replaces the set of seen transaction IDs.
+func (r *Repository) SaveSeenTransactions(ids map[string]bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.seenTx != nil && maps.Equal(r.seenTx, ids) {
+		return
+	}
+	r.seenTx = maps.Clone(ids)
+	r.seenTxStore.save()
+}
+
+// watchState is what PersistWatchlists keeps on disk.
+type watchState struct {
+	Watchlists map[int64]map[int]models.WatchedPlayer `json:"watchlists"`
+	Players    map[int]models.PlayerState             `json:"players"`
+}
+
+// PersistWatchlists loads the watchlists and the last known state of the
+// watched players saved at path and saves them there whenever they change.
+func (r *Repository) PersistWatchlists(path string) error {
+	return r.persist(r.watchStore, path)
+}
+
+// ReloadWatchlists replaces the watchlists with the ones saved on disk.
+func (r *Repository) ReloadWatchlists() error {
+	return r.reload(r.watchStore)
+}
+
+// AddWatch adds a player to a user's watchlist.
+func (r *Repository) AddWatch(userID int64, player models.WatchedPlayer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.watchlists[userID] == nil {
+		r.watchlists[userID] = make(map[int]models.WatchedPlayer)
+	}
+	r.watchlists[userID][player.PlayerID] = player
+	r.watchStore.save()
+}
+
+// RemoveWatch removes a player from a user's watchlist. Once nobody watches
+// the player, their last known state is forgotten too.
+func (r *Repository) RemoveWatch(userID int64, playerID int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.watchlists[userID], playerID)
+	if len(r.watchlists[userID]) == 0 {
+		delete(r.watchlists, userID)
+	}
+	defer r.watchStore.save()
+
+	for _, watchlist := range r.watchlists {
+		if _, ok := watchlist[playerID]; ok {
+			return
+		}
+	}
+	delete(r.watched, playerID)
+}
+
+// GetWatchlist returns a user's watched players sorted by name.
+func (r *Repository) GetWatchlist(userID int64) []models.WatchedPlayer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	players := make([]models.WatchedPlayer, 0, len(r.watchlists[userID]))
+	for _, player := range r.watchlists[userID] {
+		players = append(players, player)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].Name < players[j].Name
+	})
+	return players
+}
+
+// GetWatchers returns the users watching each watched player.
+func (r *Repository) GetWatchers() map[int][]int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	watchers := make(map[int][]int64)
+	for userID, watchlist := range r.watchlists {
+		for playerID := range watchlist {
+			watchers[playerID] = append(watchers[playerID], userID)
+		}
+	}
+	return watchers
+}
+
+// GetWatchedState returns the last known state of a watched player.
+func (r *Repository) GetWatchedState(playerID int) (models.PlayerState, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	state, ok := r.watched[playerID]
+	return state, ok
+}
+
+func (r *Repository) SaveWatchedState(state models.PlayerState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.watched[state.PlayerID] == state {
+		return
+	}
+	r.watched[state.PlayerID] = state
+	r.watchStore.save()
+}
+
+// jobState is what PersistJobs keeps on disk.
+type jobState struct {
+	Paused []string        `json:"paused"`
+	Runs   []models.JobRun `json:"runs"`
+}
+
+// PersistJobs loads the paused jobs and job runs saved at path and saves
+// them there whenever they change, so they survive restarts.
+func (r *Repository) PersistJobs(path string) error {
+	return r.persist(r.jobStore, path)
+}
+
+// ReloadJobs replaces the paused jobs and job runs with the ones saved on
+// disk, e.g. after another instance has been running the jobs.
+func (r *Repository) ReloadJobs() error {
+	return r.reload(r.jobStore)
+}
+
+// SetJobPaused pauses or resumes a scheduled job by name.
+func (r *Repository) SetJobPaused(name string, paused bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if paused {
+		r.pausedJobs[name] = true
+	} else {
+		delete(r.pausedJobs, name)
+	}
+	r.jobStore.save()
+}
+
+func (r *Repository) IsJobPaused(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.pausedJobs[name]
+}
+
+// AddJobRun records an attempt at a job run, dropping the oldest runs once
+// there are more than maxJobRuns.
+func (r *Repository) AddJobRun(run models.JobRun) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jobRuns = append(r.jobRuns, run)
+	if len(r.jobRuns) > maxJobRuns {
+		r.jobRuns = slices.Clone(r.jobRuns[len(r.jobRuns)-maxJobRuns:])
+	}
+	r.jobStore.save()
+}
+
+// GetLastJobRun returns the most recent attempt at a job.
+func (r *Repository) GetLastJobRun(name string) (models.JobRun, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := len(r.jobRuns) - 1; i >= 0; i-- {
+		if r.jobRuns[i].Job == name {
+			return r.jobRuns[i], true
+		}
+	}
+	return models.JobRun{}, false
+}
+
+// HasJobRun reports whether the run of a job due at scheduled is done: one
+// of its attempts succeeded or was skipped. Failed attempts don't count,
+// since their retries are lost on restart, and neither do runs that were
+// only waiting for approval.
+func (r *Repository) HasJobRun(name string, scheduled time.Time) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.ContainsFunc(r.jobRuns, func(run models.JobRun) bool {
+		done := run.Status == models.JobRunSucceeded || run.Status == models.JobRunSkipped
+		return run.Job == name && run.Scheduled.Equal(scheduled) && done
+	})
+}
+
+// HasJobRuns reports whether any job run has been recorded.
+func (r *Repository) HasJobRuns() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.jobRuns) > 0
+}
+
+// PersistOutbound loads the scheduled posts saved at path and saves them
+// there whenever they change.
+func (r *Repository) PersistOutbound(path string) error {
+	return r.persist(r.outStore, path)
+}
+
+// ReloadOutbound replaces the scheduled posts with the ones saved on disk.
+func (r *Repository) ReloadOutbound() error {
+	return r.reload(r.outStore)
+}
+
+func sameOutboundKey(a, b models.OutboundKey) bool {
+	return a.Report == b.Report && a.Week == b.Week && a.ChatID == b.ChatID && a.Run.Equal(b.Run)
+}
+
+// GetOutbound returns the post recorded for key.
+func (r *Repository) GetOutbound(key models.OutboundKey) (models.OutboundMessage, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, message := range r.outbound {
+		if sameOutboundKey(message.Key, key) {
+			return message, true
+		}
+	}
+	return models.OutboundMessage{}, false
+}
+
+// SaveOutbound records a post, replacing the one with the same key, and
+// drops the oldest posts once there are more than maxOutbound.
+func (r *Repository) SaveOutbound(message models.OutboundMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.outbound = slices.DeleteFunc(r.outbound, func(m models.OutboundMessage) bool {
+		return sameOutboundKey(m.Key, message.Key)
+	})
+	r.outbound = append(r.outbound, message)
+	if len(r.outbound) > maxOutbound {
+		r.outbound = slices.Clone(r.outbound[len(r.outbound)-maxOutbound:])
+	}
+	r.outStore.save()
+}
+
+func (r *Repository) DeleteOutbound(key models.OutboundKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.outbound = slices.DeleteFunc(r.outbound, func(m models.OutboundMessage) bool {
+		return sameOutboundKey(m.Key, key)
+	})
+	r.outStore.save()
+}
+
+// FindOutbound returns the posts of a report, by report type or schedule
+// name, for a week. Week 0 means the latest week the report was posted for.
+func (r *Repository) FindOutbound(report string, week int) []models.OutboundMessage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found []models.OutboundMessage
+	for _, message := range r.outbound {
+		if message.Key.Report == report || message.Name == report {
+			found = append(found, message)
+		}
+	}
+
+	if week == 0 {
+		for _, message := range found {
+			week = max(week, message.Key.Week)
+		}
+	}
+	return slices.DeleteFunc(found, func(m models.OutboundMessage) bool {
+		return m.Key.Week != week
+	})
+}
+
+// PersistAlertChats loads the chats subscribed to live game alerts saved at
+// path and saves them there whenever they change.
+func (r *Repository) PersistAlertChats(path string) error {
+	return r.persist(r.alertStore, path)
+}
+
+// ReloadAlertChats replaces the alert subscriptions with the ones saved on
+// disk.
+func (r *Repository) ReloadAlertChats() error {
+	return r.reload(r.alertStore)
+}
+
+// saveAlertChats writes the alert subscriptions to disk. Callers must hold
+// the lock.
+func (r *Repository) saveAlertChats() {
+	if r.alertStore.save() {
+		r.alertSaved = true
+	}
+}
+
+// AddDefaultAlertChat subscribes chatID to live game alerts unless the
+// subscriptions were saved before, so a chat that turned alerts off stays
+// off after a restart.
+func (r *Repository) AddDefaultAlertChat(chatID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.alertSaved {
+		return
+	}
+	r.alertChats[chatID] = true
+	r.saveAlertChats()
+}
+
+func (r *Repository) AddAlertChat(chatID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.alertChats[chatID] {
+		return
+	}
+	r.alertChats[chatID] = true
+	r.saveAlertChats()
+}
+
+func (r *Repository) RemoveAlertChat(chatID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.alertChats[chatID] {
+		return
+	}
+	delete(r.alertChats, chatID)
+	r.saveAlertChats()
+}
+
+// GetAlertChats returns the chats subscribed to live game alerts.
+func (r *Repository) GetAlertChats() []int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	chats := make([]int64, 0, len(r.alertChats))
+	for chatID := range r.alertChats {
+		chats = append(chats, chatID)
+	}
+	sort.Slice(chats, func(i, j int) bool { return chats[i] < chats[j] })
+	return chats
+}
+
+// Health reports whether the repository can be locked and its data directory
+// written to.
+func (r *Repository) Health(ctx context.Context) health.Result {
+	locked := make(chan struct{})
+	go func() {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-ctx.Done():
+		return health.Failing("repository is locked up")
+	}
+
+	r.mu.RLock()
+	paths := []string{r.jobStore.path, r.outStore.path}
+	r.mu.RUnlock()
+
+	dirs := make(map[string]bool)
+	for _, path := range paths {
+		if path == "" || dirs[filepath.Dir(path)] {
+			continue
+		}
+		dir := filepath.Dir(path)
+		dirs[dir] = true
+
+		probe, err := os.CreateTemp(dir, ".health-*")
+		if err != nil {
+			return health.Failing(fmt.Sprintf("data directory %s isn't writable: %v", dir, err))
+		}
+		probe.Close()
+		os.Remove(probe.Name())
+	}
+	return health.OK("")
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// store keeps one part of the repository in its own JSON file. Its methods
+// must be called with the repository's write lock held.
+type store struct {
+	name  string
+	path  string
+	state func() any
+	load  func(path string) error
+}
+
+// newStore creates a store that saves what state returns and hands what it
+// loads to load. A missing file loads T's zero value.
+func newStore[T any](name string, state func() T, load func(T)) *store {
+	return &store{
+		name:  name,
+		state: func() any { return state() },
+		load: func(path string) error {
+			var v T
+			if err := readJSON(path, &v); err != nil {
+				return err
+			}
+			load(v)
+			return nil
+		},
+	}
+}
+
+// persist loads what was saved at path and saves there from now on.
+func (r *Repository) persist(s *store, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating data directory: %w", err)
+	}
+
+	r.mu.Lock()
+	s.path = path
+	r.mu.Unlock()
+
+	return r.reload(s)
+}
+
+// reload replaces the part of the repository s keeps with what is saved on
+// disk.
+func (r *Repository) reload(s *store) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s.path == "" {
+		return nil
+	}
+	if err := s.load(s.path); err != nil {
+		return fmt.Errorf("loading %s: %w", s.name, err)
+	}
+	return nil
+}
+
+// save writes the current state to disk and reports whether it did.
+func (s *store) save() bool {
+	if s.path == "" {
+		return false
+	}
+	if err := writeJSON(s.path, s.state()); err != nil {
+		slog.Error("Failed to save "+s.name, "path", s.path, "error", err)
+		return false
+	}
+	return true
+}
+
+// readJSON decodes the file at path into v, leaving v alone if the file
+// doesn't exist.
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeJSON replaces the file at path with v encoded as JSON, in one step so
+// a crash never leaves half a file behind.
+func writeJSON(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
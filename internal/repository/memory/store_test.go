@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// persistAll points every store of r at dir, the way main does.
+func persistAll(t *testing.T, r *Repository, dir string) {
+	t.Helper()
+	for name, persist := range map[string]func(string) error{
+		"jobs.json":         r.PersistJobs,
+		"outbound.json":     r.PersistOutbound,
+		"watchlists.json":   r.PersistWatchlists,
+		"results.json":      r.PersistResults,
+		"announced.json":    r.PersistAnnouncedScores,
+		"transactions.json": r.PersistSeenTransactions,
+		"alerts.json":       r.PersistAlertChats,
+	} {
+		if err := persist(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("persisting %s: %v", name, err)
+		}
+	}
+}
+
+func TestStoresSurviveRestart(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	run := time.Date(2025, time.September, 16, 9, 0, 0, 0, time.UTC)
+	key := models.OutboundKey{Report: "standings", Week: 2, ChatID: -100, Run: run}
+
+	before := NewRepository()
+	persistAll(t, before, dir)
+	before.SetJobPaused("standings", true)
+	before.AddJobRun(models.JobRun{Job: "standings", Scheduled: run, Status: models.JobRunSucceeded})
+	before.SaveOutbound(models.OutboundMessage{Key: key, Job: "standings", MessageIDs: []int{7, 8}})
+	before.AddWatch(42, models.WatchedPlayer{PlayerID: 1, Name: "J. Allen"})
+	before.SaveMatchupResultState(models.MatchupResultState{MatchID: 3, Week: 2, Tracked: true, Decided: true})
+	before.SaveAnnouncedScores(models.FinalScoreReport{Week: 2})
+	before.SaveSeenTransactions(map[string]bool{"a": true, "b": true})
+	before.AddAlertChat(-200)
+
+	after := NewRepository()
+	persistAll(t, after, dir)
+
+	if !after.IsJobPaused("standings") {
+		t.Error("paused job was lost")
+	}
+	if got, ok := after.GetLastJobRun("standings"); !ok || !got.Scheduled.Equal(run) {
+		t.Errorf("last job run = %+v, %v", got, ok)
+	}
+	if got, ok := after.GetOutbound(key); !ok || !reflect.DeepEqual(got.MessageIDs, []int{7, 8}) {
+		t.Errorf("outbound = %+v, %v", got, ok)
+	}
+	if got := after.GetWatchlist(42); len(got) != 1 || got[0].Name != "J. Allen" {
+		t.Errorf("watchlist = %+v", got)
+	}
+	if got, _ := after.GetMatchupResultState(3); !got.Decided {
+		t.Errorf("matchup result = %+v", got)
+	}
+	if _, ok := after.GetAnnouncedScores(2); !ok {
+		t.Error("announced scores were lost")
+	}
+	if got, ok := after.GetSeenTransactions(); !ok || !reflect.DeepEqual(got, map[string]bool{"a": true, "b": true}) {
+		t.Errorf("seen transactions = %v, %v", got, ok)
+	}
+	// The saved subscriptions win over the default chat.
+	after.AddDefaultAlertChat(-100)
+	if got := after.GetAlertChats(); !reflect.DeepEqual(got, []int64{-200}) {
+		t.Errorf("alert chats = %v, want [-200]", got)
+	}
+}
+
+func TestStoreWithoutSavedFile(t *testing.T) {
+	r := NewRepository()
+	persistAll(t, r, t.TempDir())
+
+	if _, ok := r.GetSeenTransactions(); ok {
+		t.Error("seen transactions without a saved file, want a fresh start")
+	}
+	r.AddDefaultAlertChat(-100)
+	if got := r.GetAlertChats(); !reflect.DeepEqual(got, []int64{-100}) {
+		t.Errorf("alert chats = %v, want the default [-100]", got)
+	}
+}
+
+func TestStoreRejectsCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewRepository().PersistJobs(path); err == nil {
+		t.Error("PersistJobs with a corrupt file succeeded")
+	}
+}
@@ -0,0 +1,217 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/omarshaarawi/coachbot/internal/metrics"
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// approval is a run of a job that needs approval, waiting for an admin to
+// approve, edit or skip its report. previews holds the messages each admin
+// was sent the report as. id is random rather than counted from 1, so the
+// buttons of a preview sent before a restart can't act on a new approval.
+type approval struct {
+	id       int
+	run      run
+	deadline time.Time
+	previews map[int64][]int
+}
+
+// approvalTag marks the run that posts an approval's report when nobody has
+// decided in time.
+func approvalTag(id int) string {
+	return fmt.Sprintf("approval-%d", id)
+}
+
+// requestApproval sends r's report to the admins and posts it once the
+// approval timeout has passed, unless an admin decides first.
+func (s *Scheduler) requestApproval(r run) error {
+	if len(s.adminIDs) == 0 {
+		return errors.New("there are no admins to approve it")
+	}
+
+	s.mu.Lock()
+	id := rand.Int()
+	for s.approvals[id] != nil {
+		id = rand.Int()
+	}
+	a := &approval{
+		id:       id,
+		run:      r,
+		deadline: time.Now().Add(s.cfg.ApprovalTimeout),
+		previews: make(map[int64][]int),
+	}
+	s.approvals[a.id] = a
+	s.mu.Unlock()
+
+	_, err := s.s.NewJob(
+		gocron.OneTimeJob(gocron.OneTimeJobStartDateTime(a.deadline)),
+		gocron.NewTask(s.approvalTimedOut, a.id),
+		gocron.WithName(r.job.Name),
+		gocron.WithTags(runTag, r.job.Name, approvalTag(a.id)),
+	)
+	if err != nil {
+		s.mu.Lock()
+		delete(s.approvals, a.id)
+		s.mu.Unlock()
+		return fmt.Errorf("failed to schedule auto-publish: %w", err)
+	}
+
+	s.sendPreviews(a)
+	return nil
+}
+
+// sendPreviews sends the report an approval would post to each admin, after
+// a note saying where and when it goes out.
+func (s *Scheduler) sendPreviews(a *approval) {
+	s.mu.Lock()
+	report := a.run.shown()
+	s.mu.Unlock()
+
+	note := fmt.Sprintf("👀 Preview of %s. It's posted at %s unless you skip it.",
+		a.run.job.Name, a.deadline.In(s.location).Format("Mon 15:04"))
+	for _, adminID := range s.adminIDs {
+		if _, err := s.messenger.SendReport(adminID, models.Message{Text: note}); err != nil {
+			slog.Error("Failed to send preview", "job", a.run.job.Name, "admin", adminID, "error", err)
+			continue
+		}
+		ids, err := s.messenger.SendPreview(adminID, report, a.id)
+		if err != nil {
+			slog.Error("Failed to send preview", "job", a.run.job.Name, "admin", adminID, "error", err)
+		}
+
+		s.mu.Lock()
+		a.previews[adminID] = ids
+		s.mu.Unlock()
+	}
+}
+
+// closePreviews replaces the admins' previews with note, except for the
+// last message of the admin who decided, which their reply replaces.
+func (s *Scheduler) closePreviews(a *approval, adminID int64, note string) {
+	s.mu.Lock()
+	previews := make(map[int64][]int, len(a.previews))
+	for chatID, ids := range a.previews {
+		previews[chatID] = ids
+	}
+	s.mu.Unlock()
+
+	for chatID, ids := range previews {
+		if chatID == adminID && len(ids) > 0 {
+			ids = ids[:len(ids)-1]
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		if _, err := s.messenger.ReplaceReport(chatID, ids, models.Message{Text: note}); err != nil {
+			slog.Error("Failed to close preview", "job", a.run.job.Name, "admin", chatID, "error", err)
+		}
+	}
+}
+
+// takeApproval removes a pending approval and its auto-publish run.
+func (s *Scheduler) takeApproval(id int) (*approval, error) {
+	s.mu.Lock()
+	a, ok := s.approvals[id]
+	delete(s.approvals, id)
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, errors.New("this report was already posted or skipped")
+	}
+	s.s.RemoveByTags(approvalTag(id))
+	return a, nil
+}
+
+// Preview returns the report an approval would post.
+func (s *Scheduler) Preview(id int) (models.Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.approvals[id]
+	if !ok {
+		return nil, errors.New("this report was already posted or skipped")
+	}
+	return a.run.shown(), nil
+}
+
+// Approve posts an approval's report now. adminID and by are the admin who
+// approved it, by the name the other admins are shown.
+func (s *Scheduler) Approve(id int, adminID int64, by string) error {
+	a, err := s.takeApproval(id)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Report approved", "job", a.run.job.Name, "by", by)
+	go func() {
+		s.closePreviews(a, adminID, fmt.Sprintf("✅ %s was approved by %s.", a.run.job.Name, by))
+		a.run.approved = true
+		s.attempt(a.run)
+	}()
+	return nil
+}
+
+// Skip drops an approval's report without posting it.
+func (s *Scheduler) Skip(id int, adminID int64, by string) error {
+	a, err := s.takeApproval(id)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Report skipped", "job", a.run.job.Name, "by", by)
+	s.repo.AddJobRun(models.JobRun{
+		Job:       a.run.job.Name,
+		Report:    a.run.report,
+		Scheduled: a.run.scheduled,
+		Started:   time.Now(),
+		Attempt:   a.run.attempt,
+		CatchUp:   a.run.catchUp,
+		Status:    models.JobRunSkipped,
+		Error:     "skipped by " + by,
+	})
+	metrics.JobRuns.WithLabelValues(a.run.job.Name, string(models.JobRunSkipped)).Inc()
+	go s.closePreviews(a, adminID, fmt.Sprintf("⏭ %s was skipped by %s.", a.run.job.Name, by))
+	return nil
+}
+
+// EditPreview replaces an approval's report with text and sends the admins
+// a new preview of it. It's still posted when the original timeout passes.
+func (s *Scheduler) EditPreview(id int, adminID int64, by string, text string) error {
+	s.mu.Lock()
+	a, ok := s.approvals[id]
+	if ok {
+		a.run.edited = models.Message{Text: text}
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return errors.New("this report was already posted or skipped")
+	}
+
+	slog.Info("Report edited", "job", a.run.job.Name, "by", by)
+	go func() {
+		s.closePreviews(a, 0, fmt.Sprintf("✏️ %s was edited by %s, see the new preview.", a.run.job.Name, by))
+		s.sendPreviews(a)
+	}()
+	return nil
+}
+
+// approvalTimedOut posts an approval's report when no admin decided in time.
+func (s *Scheduler) approvalTimedOut(id int) {
+	a, err := s.takeApproval(id)
+	if err != nil {
+		return
+	}
+
+	slog.Info("Nobody approved report in time, posting it", "job", a.run.job.Name)
+	s.closePreviews(a, 0, fmt.Sprintf("⏰ %s was posted automatically.", a.run.job.Name))
+	a.run.approved = true
+	s.attempt(a.run)
+}
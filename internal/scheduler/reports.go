@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/service"
+)
+
+// report is something a job can post.
+type report struct {
+	// build returns the report, or false when there is nothing to post.
+	build func(*service.FantasyService) (models.Report, bool, error)
+	// sent, if set, runs once the report was posted.
+	sent func(*service.FantasyService, models.Report)
+	// repeats marks reports posted more than once a week; every run gets a
+	// post of its own instead of editing the week's earlier post.
+	repeats bool
+	// amends, if set, returns the corrected version of an earlier report
+	// that the posted report announced a change to, which replaces that
+	// report's posts.
+	amends func(*service.FantasyService, models.Report) (models.Report, bool)
+}
+
+// reports maps the report names used in the schedule to what they post.
+var reports = map[string]report{
+	"scoreboard": {
+		build: func(s *service.FantasyService) (models.Report, bool, error) {
+			r, err := s.GetCurrentScores()
+			return r, true, err
+		},
+		repeats: true,
+	},
+	"close_games": {
+		build: func(s *service.FantasyService) (models.Report, bool, error) {
+			r, err := s.GetMondayNightCloseGames()
+			return r, true, err
+		},
+	},
+	"trophies": {
+		build: func(s *service.FantasyService) (models.Report, bool, error) {
+			r, err := s.GetFinalScoreReport()
+			return r, true, err
+		},
+		sent: func(s *service.FantasyService, r models.Report) {
+			s.RecordAnnouncedScores(r.(models.FinalScoreReport))
+		},
+	},
+	"standings": {
+		build: func(s *service.FantasyService) (models.Report, bool, error) {
+			r, err := s.GetStandings()
+			return r, true, err
+		},
+	},
+	"playoff_bracket": {
+		build: func(s *service.FantasyService) (models.Report, bool, error) {
+			r, err := s.GetPlayoffBracket()
+			return r, true, err
+		},
+	},
+	"matchups": {
+		build: func(s *service.FantasyService) (models.Report, bool, error) {
+			r, err := s.GetMatchups()
+			return r, true, err
+		},
+	},
+	"players_to_monitor": {
+		build: func(s *service.FantasyService) (models.Report, bool, error) {
+			r, err := s.GetPlayersToMonitor()
+			return r, true, err
+		},
+	},
+	"free_agents": {
+		build: func(s *service.FantasyService) (models.Report, bool, error) {
+			r, err := s.GetFreeAgents("")
+			return r, true, err
+		},
+	},
+	"stat_corrections": {
+		build: func(s *service.FantasyService) (models.Report, bool, error) {
+			return s.CheckStatCorrections()
+		},
+		sent: func(s *service.FantasyService, r models.Report) {
+			s.RecordAnnouncedScores(r.(models.StatCorrectionReport).Corrected)
+		},
+		amends: func(s *service.FantasyService, r models.Report) (models.Report, bool) {
+			return s.GetAnnouncedScores(r.(models.StatCorrectionReport).Week)
+		},
+	},
+}
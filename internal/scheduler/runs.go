@@ -0,0 +1,364 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/omarshaarawi/coachbot/internal/metrics"
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// maxRetryBackoff caps the doubling delay between retries.
+const maxRetryBackoff = 15 * time.Minute
+
+// run is a job run due at scheduled. A retry keeps the report picked for the
+// first attempt and only goes to the chats that didn't get it.
+type run struct {
+	job       Job
+	scheduled time.Time
+	attempt   int
+	catchUp   bool
+	report    string
+	chats     []int64
+
+	// An approved run posts the report the admins saw instead of building
+	// it again: built, or the text an admin replaced it with.
+	approved bool
+	built    models.Report
+	edited   models.Report
+}
+
+// shown is the report an approved run posts.
+func (r run) shown() models.Report {
+	if r.edited != nil {
+		return r.edited
+	}
+	return r.built
+}
+
+func (s *Scheduler) runJob(job Job, scheduled time.Time) {
+	s.attempt(run{job: job, scheduled: scheduled, attempt: 1, chats: job.Chats})
+}
+
+// attempt runs r once and records the outcome. A failed run is retried with
+// backoff until the retry window since it was due has passed, and admins are
+// told about the final failure.
+func (s *Scheduler) attempt(r run) {
+	started := time.Now()
+	status, err := s.execute(&r)
+
+	record := models.JobRun{
+		Job:       r.job.Name,
+		Report:    r.report,
+		Scheduled: r.scheduled,
+		Started:   started,
+		Duration:  time.Since(started),
+		Attempt:   r.attempt,
+		CatchUp:   r.catchUp,
+		Status:    status,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	s.repo.AddJobRun(record)
+	metrics.JobRuns.WithLabelValues(r.job.Name, string(status)).Inc()
+
+	switch status {
+	case models.JobRunSkipped:
+		slog.Info("Skipping job", "job", r.job.Name, "reason", err)
+		return
+	case models.JobRunPending:
+		slog.Info("Waiting for approval", "job", r.job.Name)
+		return
+	case models.JobRunSucceeded:
+		return
+	}
+
+	backoff := min(s.cfg.RetryBackoff<<(r.attempt-1), maxRetryBackoff)
+	retryAt := time.Now().Add(backoff)
+	if retryAt.After(r.scheduled.Add(s.cfg.RetryWindow)) {
+		slog.Error("Job failed", "job", r.job.Name, "attempt", r.attempt, "error", err)
+		metrics.JobFailures.WithLabelValues(r.job.Name).Inc()
+		s.notifyAdmins(fmt.Sprintf("⚠️ Job %s failed after %d attempts: %v", r.job.Name, r.attempt, err))
+		return
+	}
+
+	slog.Warn("Job failed, retrying", "job", r.job.Name, "attempt", r.attempt, "retry_at", retryAt, "error", err)
+	r.attempt++
+	_, jobErr := s.s.NewJob(
+		gocron.OneTimeJob(gocron.OneTimeJobStartDateTime(retryAt)),
+		gocron.NewTask(s.attempt, r),
+		gocron.WithName(r.job.Name),
+		gocron.WithTags(runTag, r.job.Name),
+	)
+	if jobErr != nil {
+		slog.Error("Failed to schedule retry", "job", r.job.Name, "error", jobErr)
+	}
+}
+
+// execute posts the job's report, or sends it for approval if the job needs
+// it. The first attempt picks the report for the current season phase; a
+// partly delivered report narrows r.chats to the chats that still need it.
+func (s *Scheduler) execute(r *run) (models.JobRunStatus, error) {
+	if s.repo.IsJobPaused(r.job.Name) {
+		return models.JobRunSkipped, errors.New("job is paused")
+	}
+
+	if r.report == "" {
+		phase, err := s.fantasyService.GetSeasonPhase()
+		if err != nil {
+			return models.JobRunFailed, err
+		}
+		report, ok := r.job.reportFor(phase)
+		if !ok {
+			return models.JobRunSkipped, fmt.Errorf("job doesn't run in the %s phase", phase)
+		}
+		r.report = report
+	}
+
+	if !r.approved {
+		report, ok, err := s.build(r.report)
+		if err != nil {
+			return models.JobRunFailed, err
+		}
+		if !ok {
+			slog.Info("Nothing to send", "job", r.job.Name)
+			return models.JobRunSucceeded, nil
+		}
+		r.built = report
+
+		if r.job.Approve {
+			err := s.requestApproval(*r)
+			if err == nil {
+				return models.JobRunPending, nil
+			}
+			slog.Warn("Posting without approval", "job", r.job.Name, "error", err)
+		}
+	}
+
+	failed, err := s.publish(r.job, r.report, r.built, r.shown(), r.chats, r.scheduled)
+	if err != nil {
+		r.chats = failed
+		return models.JobRunFailed, err
+	}
+	return models.JobRunSucceeded, nil
+}
+
+// build returns the named report, or false when there is nothing to post.
+func (s *Scheduler) build(name string) (models.Report, bool, error) {
+	report, ok, err := reports[name].build(s.fantasyService)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get %s: %w", name, err)
+	}
+	return report, ok, nil
+}
+
+// post builds the named report and sends it to chats, or the league chat if
+// there are none. It returns the chats the report didn't reach.
+func (s *Scheduler) post(job Job, name string, chats []int64, scheduled time.Time) ([]int64, error) {
+	report, ok, err := s.build(name)
+	if err != nil {
+		return chats, err
+	}
+	if !ok {
+		slog.Info("Nothing to send", "job", job.Name)
+		return nil, nil
+	}
+	return s.publish(job, name, report, report, chats, scheduled)
+}
+
+// publish sends shown, the built report or the text an admin replaced it
+// with, to chats, or the league chat if there are none. Posts are logged
+// and follow-ups run for the built report. It returns the chats the report
+// didn't reach.
+func (s *Scheduler) publish(job Job, name string, built, shown models.Report, chats []int64, scheduled time.Time) ([]int64, error) {
+	if len(chats) == 0 {
+		chats = []int64{s.chatID}
+	}
+
+	week, err := s.fantasyService.GetCurrentWeek()
+	if err != nil {
+		return chats, fmt.Errorf("failed to get current week: %w", err)
+	}
+
+	slog.Info("Sending report", "job", job.Name, "report", name, "time", time.Now().Format(time.RFC3339))
+
+	r := reports[name]
+	var failed []int64
+	var errs []error
+	for _, chatID := range chats {
+		key := models.OutboundKey{Report: built.ReportType(), Week: reportWeek(built, week), ChatID: chatID}
+		if r.repeats {
+			key.Run = scheduled
+		}
+		if err := s.deliver(key, job, name, shown); err != nil {
+			failed = append(failed, chatID)
+			errs = append(errs, fmt.Errorf("failed to send %s to %d: %w", name, chatID, err))
+		}
+	}
+
+	if len(failed) < len(chats) {
+		if r.sent != nil {
+			r.sent(s.fantasyService, built)
+		}
+		if r.amends != nil {
+			if amended, ok := r.amends(s.fantasyService, built); ok {
+				s.amend(amended)
+			}
+		}
+	}
+	return failed, errors.Join(errs...)
+}
+
+// reportWeek is the week a report is about, which for reports that don't
+// say is the current one.
+func reportWeek(report models.Report, current int) int {
+	switch r := report.(type) {
+	case models.ScoresReport:
+		return r.Week
+	case models.MatchupsReport:
+		return r.Week
+	case models.FinalScoreReport:
+		return r.Week
+	case models.StatCorrectionReport:
+		return r.Week
+	default:
+		return current
+	}
+}
+
+// deliver posts report, or edits the earlier post with the same key, so a
+// retried or repeated run doesn't post the same report twice.
+func (s *Scheduler) deliver(key models.OutboundKey, job Job, name string, report models.Report) error {
+	var ids []int
+	var err error
+	if posted, ok := s.repo.GetOutbound(key); ok {
+		slog.Info("Editing earlier post", "job", job.Name, "report", name, "chat", key.ChatID)
+		ids, err = s.messenger.ReplaceReport(key.ChatID, posted.MessageIDs, report)
+	} else {
+		ids, err = s.messenger.PostReport(key.ChatID, report)
+	}
+
+	// Record even a partial post, so the retry edits it instead of
+	// starting over.
+	if len(ids) > 0 {
+		s.repo.SaveOutbound(models.OutboundMessage{
+			Key:        key,
+			Job:        job.Name,
+			Name:       name,
+			MessageIDs: ids,
+			Sent:       time.Now(),
+		})
+	}
+	return err
+}
+
+// amend replaces the week's posts of a report with its corrected version.
+func (s *Scheduler) amend(report models.Report) {
+	for _, posted := range s.repo.FindOutbound(report.ReportType(), reportWeek(report, 0)) {
+		ids, err := s.messenger.ReplaceReport(posted.Key.ChatID, posted.MessageIDs, report)
+		if len(ids) > 0 {
+			posted.MessageIDs = ids
+			s.repo.SaveOutbound(posted)
+		}
+		if err != nil {
+			slog.Error("Failed to correct earlier post", "report", posted.Name, "chat", posted.Key.ChatID, "error", err)
+		}
+	}
+}
+
+// Retract deletes the latest post of a report, by report type or schedule
+// name, from every chat it went to. Week 0 means the latest week it was
+// posted for. It returns how many messages were deleted.
+func (s *Scheduler) Retract(report string, week int) (int, error) {
+	posts := s.repo.FindOutbound(report, week)
+	if len(posts) == 0 {
+		return 0, fmt.Errorf("no posts of %s to retract", report)
+	}
+
+	// Reports posted several times a week only lose their latest post.
+	var latest time.Time
+	for _, posted := range posts {
+		if posted.Key.Run.After(latest) {
+			latest = posted.Key.Run
+		}
+	}
+
+	deleted := 0
+	var errs []error
+	for _, posted := range posts {
+		if !posted.Key.Run.Equal(latest) {
+			continue
+		}
+		if err := s.messenger.DeleteMessages(posted.Key.ChatID, posted.MessageIDs); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		s.repo.DeleteOutbound(posted.Key)
+		deleted += len(posted.MessageIDs)
+	}
+	return deleted, errors.Join(errs...)
+}
+
+func (s *Scheduler) notifyAdmins(text string) {
+	for _, adminID := range s.adminIDs {
+		if _, err := s.messenger.SendReport(adminID, models.Message{Text: text}); err != nil {
+			slog.Error("Failed to notify admin", "admin", adminID, "error", err)
+		}
+	}
+}
+
+// catchUp runs each job whose last due run was missed while the bot was
+// down, once, if it was due within the job's catch-up window. Nothing is
+// made up before any run has been recorded, since there's no telling what
+// was missed.
+func (s *Scheduler) catchUp() {
+	if !s.repo.HasJobRuns() {
+		return
+	}
+
+	s.mu.Lock()
+	schedule := s.schedule
+	s.mu.Unlock()
+
+	var weeks [][]models.ProGame
+	now := time.Now()
+	for _, job := range schedule.Jobs {
+		if !job.enabled() {
+			continue
+		}
+
+		var due time.Time
+		var ok bool
+		if len(job.Triggers) > 0 {
+			if weeks == nil {
+				var err error
+				if weeks, err = s.fantasyService.GetNearbyProGames(); err != nil {
+					slog.Error("Failed to check for missed game-time jobs", "error", err)
+					weeks = [][]models.ProGame{}
+				}
+			}
+			due, ok = lastTriggered(job.Triggers, weeks, now, s.eastern)
+		} else {
+			due, ok = job.lastDue(now, schedule.Timezone)
+		}
+
+		if !ok || now.Sub(due) > job.catchUpWindow(s.cfg.CatchUpWindow) || s.repo.HasJobRun(job.Name, due) {
+			continue
+		}
+
+		slog.Info("Catching up on missed job", "job", job.Name, "due", due)
+		_, err := s.s.NewJob(
+			gocron.OneTimeJob(gocron.OneTimeJobStartImmediately()),
+			gocron.NewTask(s.attempt, run{job: job, scheduled: due, attempt: 1, catchUp: true, chats: job.Chats}),
+			gocron.WithName(job.Name),
+			gocron.WithTags(runTag, job.Name),
+		)
+		if err != nil {
+			slog.Error("Failed to schedule catch-up run", "job", job.Name, "error", err)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// Schedule is the set of report jobs, loaded from a JSON file:
+//
+//	{
+//	  "timezone": "America/Chicago",
+//	  "jobs": [
+//	    {"name": "standings", "report": "standings", "days": ["wednesday"], "times": ["07:30"],
+//	     "phases": ["regular_season", "playoffs"], "phase_reports": {"playoffs": "playoff_bracket"}}
+//	  ]
+//	}
+type Schedule struct {
+	Timezone string `json:"timezone"`
+	Jobs     []Job  `json:"jobs"`
+}
+
+// Job posts a report at the given times on the given days, or at Triggers
+// tied to the NFL schedule instead. Timezone overrides the schedule's, Chats
+// the league chat, and Enabled defaults to true. CatchUp overrides how late
+// a run missed during downtime may still be made up, "0s" turning it off.
+// The job only runs in the season phases listed in Phases, the regular
+// season and playoffs by default, and PhaseReports swaps in a different
+// report for a phase. With Approve, each report is sent to the admins to
+// approve, edit or skip before it's posted.
+type Job struct {
+	Name         string            `json:"name"`
+	Report       string            `json:"report"`
+	Days         []string          `json:"days,omitempty"`
+	Times        []string          `json:"times,omitempty"`
+	Triggers     []Trigger         `json:"triggers,omitempty"`
+	Timezone     string            `json:"timezone,omitempty"`
+	Chats        []int64           `json:"chats,omitempty"`
+	Enabled      *bool             `json:"enabled,omitempty"`
+	Phases       []string          `json:"phases,omitempty"`
+	PhaseReports map[string]string `json:"phase_reports,omitempty"`
+	CatchUp      string            `json:"catch_up,omitempty"`
+	Approve      bool              `json:"approve,omitempty"`
+}
+
+func (j Job) enabled() bool {
+	return j.Enabled == nil || *j.Enabled
+}
+
+var defaultPhases = []string{string(models.PhaseRegularSeason), string(models.PhasePlayoffs)}
+
+var phases = []models.SeasonPhase{
+	models.PhasePreseason,
+	models.PhaseRegularSeason,
+	models.PhasePlayoffs,
+	models.PhaseOffseason,
+}
+
+func (j Job) phases() []string {
+	if len(j.Phases) == 0 {
+		return defaultPhases
+	}
+	return j.Phases
+}
+
+// reportFor returns the report the job posts in phase, or false if the job
+// doesn't run in it.
+func (j Job) reportFor(phase models.SeasonPhase) (string, bool) {
+	if !slices.Contains(j.phases(), string(phase)) {
+		return "", false
+	}
+	if report, ok := j.PhaseReports[string(phase)]; ok {
+		return report, true
+	}
+	return j.Report, true
+}
+
+func knownPhase(phase string) bool {
+	return slices.Contains(phases, models.SeasonPhase(phase))
+}
+
+var weekdays = map[string]string{
+	"sunday":    "0",
+	"monday":    "1",
+	"tuesday":   "2",
+	"wednesday": "3",
+	"thursday":  "4",
+	"friday":    "5",
+	"saturday":  "6",
+}
+
+// crontabs returns one crontab per time of day, each pinned to the job's
+// time zone.
+func (j Job) crontabs(timezone string) ([]string, error) {
+	if j.Timezone != "" {
+		timezone = j.Timezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("job %s: %w", j.Name, err)
+	}
+
+	if len(j.Days) == 0 || len(j.Times) == 0 {
+		return nil, fmt.Errorf("job %s: needs at least one day and one time", j.Name)
+	}
+
+	days := make([]string, 0, len(j.Days))
+	for _, day := range j.Days {
+		weekday, ok := weekdays[strings.ToLower(day)]
+		if !ok {
+			return nil, fmt.Errorf("job %s: unknown day %q", j.Name, day)
+		}
+		days = append(days, weekday)
+	}
+
+	crontabs := make([]string, 0, len(j.Times))
+	for _, at := range j.Times {
+		t, err := time.Parse("15:04", at)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: time %q is not HH:MM", j.Name, at)
+		}
+		crontabs = append(crontabs, fmt.Sprintf("CRON_TZ=%s %d %d * * %s", timezone, t.Minute(), t.Hour(), strings.Join(days, ",")))
+	}
+	return crontabs, nil
+}
+
+// lastDue returns the most recent time at or before now that the job was
+// due on its days and times, the way its crontabs run across daylight
+// saving changes: a time the clocks skip isn't due that day, and a time
+// they pass twice is due at both.
+func (j Job) lastDue(now time.Time, timezone string) (time.Time, bool) {
+	if j.Timezone != "" {
+		timezone = j.Timezone
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	now = now.In(location)
+
+	var last time.Time
+	for daysAgo := 0; daysAgo <= 7; daysAgo++ {
+		day := now.AddDate(0, 0, -daysAgo)
+		if !slices.ContainsFunc(j.Days, func(d string) bool {
+			return weekdays[strings.ToLower(d)] == fmt.Sprint(int(day.Weekday()))
+		}) {
+			continue
+		}
+		for _, at := range j.Times {
+			t, err := time.Parse("15:04", at)
+			if err != nil {
+				continue
+			}
+			due := time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, location)
+			if due.Hour() != t.Hour() || due.Minute() != t.Minute() {
+				continue
+			}
+			if again := due.Add(time.Hour); again.Hour() == t.Hour() && again.Minute() == t.Minute() && !again.After(now) {
+				due = again
+			}
+			if !due.After(now) && due.After(last) {
+				last = due
+			}
+		}
+	}
+	return last, !last.IsZero()
+}
+
+func (j Job) catchUpWindow(fallback time.Duration) time.Duration {
+	if j.CatchUp == "" {
+		return fallback
+	}
+	window, _ := time.ParseDuration(j.CatchUp)
+	return window
+}
+
+// loadSchedule reads the schedule at path, falling back to the default
+// schedule when the file doesn't exist.
+func loadSchedule(path string) (Schedule, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return defaultSchedule(), nil
+	}
+	if err != nil {
+		return Schedule{}, fmt.Errorf("reading schedule: %w", err)
+	}
+
+	var schedule Schedule
+	if err := json.Unmarshal(data, &schedule); err != nil {
+		return Schedule{}, fmt.Errorf("parsing schedule %s: %w", path, err)
+	}
+	if schedule.Timezone == "" {
+		schedule.Timezone = defaultTimezone
+	}
+
+	return schedule, validate(schedule)
+}
+
+func validate(schedule Schedule) error {
+	names := make(map[string]bool)
+	for _, job := range schedule.Jobs {
+		if job.Name == "" {
+			return fmt.Errorf("every job needs a name")
+		}
+		if names[job.Name] {
+			return fmt.Errorf("job %s is defined twice", job.Name)
+		}
+		names[job.Name] = true
+
+		if _, ok := reports[job.Report]; !ok {
+			return fmt.Errorf("job %s: unknown report %q", job.Name, job.Report)
+		}
+		for _, phase := range job.Phases {
+			if !knownPhase(phase) {
+				return fmt.Errorf("job %s: unknown phase %q", job.Name, phase)
+			}
+		}
+		for phase, report := range job.PhaseReports {
+			if !knownPhase(phase) {
+				return fmt.Errorf("job %s: unknown phase %q", job.Name, phase)
+			}
+			if _, ok := reports[report]; !ok {
+				return fmt.Errorf("job %s: unknown report %q for %s", job.Name, report, phase)
+			}
+		}
+		if job.CatchUp != "" {
+			if _, err := time.ParseDuration(job.CatchUp); err != nil {
+				return fmt.Errorf("job %s: catch_up %q is not a duration", job.Name, job.CatchUp)
+			}
+		}
+
+		if len(job.Triggers) > 0 {
+			if len(job.Days) > 0 || len(job.Times) > 0 {
+				return fmt.Errorf("job %s: use either days and times or triggers", job.Name)
+			}
+			for _, trigger := range job.Triggers {
+				if err := trigger.validate(); err != nil {
+					return fmt.Errorf("job %s: %w", job.Name, err)
+				}
+			}
+			continue
+		}
+		if _, err := job.crontabs(schedule.Timezone); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const defaultTimezone = "America/Chicago"
+
+// defaultSchedule is used when there is no schedule file.
+func defaultSchedule() Schedule {
+	return Schedule{
+		Timezone: defaultTimezone,
+		Jobs: []Job{
+			{Name: "close-scores", Report: "close_games", Triggers: []Trigger{{Event: eventLastKickoff, Offset: "-90m"}}},
+			{Name: "scoreboard", Report: "scoreboard", Days: []string{"monday", "tuesday", "friday"}, Times: []string{"07:30"}},
+			{Name: "trophies", Report: "trophies", Days: []string{"tuesday"}, Times: []string{"07:30"}},
+			{Name: "free-agents", Report: "free_agents", Days: []string{"tuesday"}, Times: []string{"09:00"}},
+			{Name: "standings", Report: "standings", Days: []string{"wednesday"}, Times: []string{"07:30"}, PhaseReports: map[string]string{"playoffs": "playoff_bracket"}},
+			{Name: "matchups", Report: "matchups", Triggers: []Trigger{{Event: eventFirstKickoff, Offset: "-30m"}}},
+			{Name: "stat-corrections", Report: "stat_corrections", Days: []string{"friday"}, Times: []string{"08:00"}},
+			{Name: "players-to-monitor", Report: "players_to_monitor", Days: []string{"sunday"}, Times: []string{"07:30"}},
+			{Name: "sunday-scoreboard", Report: "scoreboard", Triggers: []Trigger{{Event: eventEarlyGamesEnd}, {Event: eventLateGamesEnd}}},
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// TestJobLastDue checks lastDue around the 2025 daylight saving changes in
+// Central time, and that it agrees with the CRON_TZ crontabs the jobs are
+// scheduled with: catch-up must only make up runs cron would have made.
+func TestJobLastDue(t *testing.T) {
+	const timezone = "America/Chicago"
+	chicago, err := time.LoadLocation(timezone)
+	if err != nil {
+		t.Fatalf("loading Central time: %v", err)
+	}
+	cdt := time.FixedZone("CDT", -5*60*60)
+	cst := time.FixedZone("CST", -6*60*60)
+
+	sunday := func(at string) Job {
+		return Job{Name: "test", Days: []string{"sunday"}, Times: []string{at}}
+	}
+
+	tests := []struct {
+		name string
+		job  Job
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "morning after DST starts",
+			job:  sunday("09:00"),
+			now:  time.Date(2025, time.March, 9, 12, 0, 0, 0, chicago),
+			want: time.Date(2025, time.March, 9, 9, 0, 0, 0, cdt),
+		},
+		{
+			name: "time skipped when DST starts falls back a week",
+			job:  sunday("02:30"),
+			now:  time.Date(2025, time.March, 9, 12, 0, 0, 0, chicago),
+			want: time.Date(2025, time.March, 2, 2, 30, 0, 0, cst),
+		},
+		{
+			name: "time repeated when DST ends, after the first pass",
+			job:  sunday("01:30"),
+			now:  time.Date(2025, time.November, 2, 1, 45, 0, 0, cdt),
+			want: time.Date(2025, time.November, 2, 1, 30, 0, 0, cdt),
+		},
+		{
+			name: "time repeated when DST ends, after the second pass",
+			job:  sunday("01:30"),
+			now:  time.Date(2025, time.November, 2, 12, 0, 0, 0, chicago),
+			want: time.Date(2025, time.November, 2, 1, 30, 0, 0, cst),
+		},
+		{
+			name: "job time zone changes on its own date",
+			job:  Job{Name: "test", Days: []string{"sunday"}, Times: []string{"08:00"}, Timezone: "Europe/London"},
+			// Europe leaves DST a week before the US.
+			now:  time.Date(2025, time.October, 26, 6, 0, 0, 0, chicago),
+			want: time.Date(2025, time.October, 26, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.job.lastDue(tt.now, timezone)
+			if !ok || !got.Equal(tt.want) {
+				t.Fatalf("lastDue = %v, %v, want %v", got, ok, tt.want)
+			}
+
+			crontabs, err := tt.job.crontabs(timezone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			schedule, err := cron.ParseStandard(crontabs[0])
+			if err != nil {
+				t.Fatalf("parsing %q: %v", crontabs[0], err)
+			}
+			if next := schedule.Next(got.Add(-time.Second)); !next.Equal(got) {
+				t.Errorf("%q runs at %v, not at the last due %v", crontabs[0], next, got)
+			}
+			if next := schedule.Next(got); !next.After(tt.now) {
+				t.Errorf("%q ran again at %v, after the last due %v", crontabs[0], next, got)
+			}
+		})
+	}
+}
+
+func TestJobLastDueWithoutTimes(t *testing.T) {
+	now := time.Date(2025, time.September, 16, 12, 0, 0, 0, time.UTC)
+	for name, job := range map[string]Job{
+		"trigger only":     {Triggers: []Trigger{{Event: eventFirstKickoff}}},
+		"unknown timezone": {Days: []string{"tuesday"}, Times: []string{"09:00"}, Timezone: "Mars/Olympus"},
+	} {
+		if got, ok := job.lastDue(now, "America/Chicago"); ok {
+			t.Errorf("%s: lastDue = %v, want none", name, got)
+		}
+	}
+}
@@ -1,201 +1,465 @@
 package scheduler
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-co-op/gocron/v2"
+	"github.com/omarshaarawi/coachbot/internal/config"
+	"github.com/omarshaarawi/coachbot/internal/health"
+	"github.com/omarshaarawi/coachbot/internal/leader"
+	"github.com/omarshaarawi/coachbot/internal/models"
+	"github.com/omarshaarawi/coachbot/internal/repository/memory"
 	"github.com/omarshaarawi/coachbot/internal/service"
 )
 
+// Messenger delivers reports to a chat.
+type Messenger interface {
+	SendReport(chatID int64, report models.Report) (int, error)
+	PostReport(chatID int64, report models.Report) ([]int, error)
+	ReplaceReport(chatID int64, messageIDs []int, report models.Report) ([]int, error)
+	DeleteMessages(chatID int64, messageIDs []int) error
+	// SendPreview sends a report to an admin with buttons to approve, edit
+	// or skip the approval with the given ID.
+	SendPreview(chatID int64, report models.Report, approvalID int) ([]int, error)
+}
+
+// scheduleTag marks the jobs that come from the schedule file, so a reload
+// can replace them without touching interval jobs added with Every.
+// gameTimeTag marks the one-off runs of jobs with triggers, which are
+// replaced whenever game times are planned, and runTag retries and catch-up
+// runs, which outlive both.
+const (
+	scheduleTag = "schedule"
+	gameTimeTag = "game-time"
+	runTag      = "run"
+)
+
+// gameTimePlanInterval is how often trigger times are worked out again, so
+// flexed games and newly set kickoffs are picked up.
+const gameTimePlanInterval = time.Hour
+
 type Scheduler struct {
 	s              gocron.Scheduler
+	location       *time.Location
+	eastern        *time.Location
 	fantasyService *service.FantasyService
-	sendMessage    func(string) error
+	repo           *memory.Repository
+	messenger      Messenger
+	chatID         int64
+	adminIDs       []int64
+	cfg            config.Scheduler
+	elector        *leader.Elector
+
+	mu        sync.Mutex
+	schedule  Schedule
+	modTime   time.Time
+	approvals map[int]*approval
+	running   bool
 }
 
-func NewScheduler(fantasyService *service.FantasyService, sendMessage func(string) error) (*Scheduler, error) {
-	location, err := time.LoadLocation("America/Chicago") // CDT
+// NewScheduler creates a scheduler for the report jobs in the schedule file.
+// Jobs without chats post to chatID, and adminIDs are told when a job has
+// failed for good. Jobs only run while elector says this instance is the
+// leader.
+func NewScheduler(fantasyService *service.FantasyService, repo *memory.Repository, messenger Messenger, chatID int64, adminIDs []int64, cfg config.Scheduler, elector *leader.Elector) (*Scheduler, error) {
+	schedule, err := loadSchedule(cfg.File)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load location: %w", err)
+	}
+
+	eastern, err := time.LoadLocation("America/New_York")
 	if err != nil {
-		slog.Error("Failed to load location", "error", err)
+		return nil, fmt.Errorf("failed to load location: %w", err)
 	}
 
 	s, err := gocron.NewScheduler(
 		gocron.WithLocation(location),
+		gocron.WithDistributedElector(elector),
 	)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create scheduler: %w", err)
 	}
 
-	return &Scheduler{
+	scheduler := &Scheduler{
 		s:              s,
+		location:       location,
+		eastern:        eastern,
 		fantasyService: fantasyService,
-		sendMessage:    sendMessage,
-	}, nil
+		repo:           repo,
+		messenger:      messenger,
+		chatID:         chatID,
+		adminIDs:       adminIDs,
+		cfg:            cfg,
+		elector:        elector,
+		schedule:       schedule,
+		modTime:        modTime(cfg.File),
+		approvals:      make(map[int]*approval),
+	}
+	elector.OnElected(scheduler.takeOver)
+	return scheduler, nil
 }
 
-func (s *Scheduler) Start() error {
-	var err error
-
-	// Close Scores - Monday 18:30 EDT (17:30 CDT)
-	_, err = s.s.NewJob(
-		gocron.WeeklyJob(1, gocron.NewWeekdays(time.Monday), gocron.NewAtTimes(gocron.NewAtTime(17, 30, 0))),
-		gocron.NewTask(s.sendCloseScores),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create close scores job: %w", err)
+// takeOver picks up the job state, posts, watchlists, matchup results,
+// announced scores, seen transactions and alert subscriptions the previous
+// leader left behind and makes up the runs nobody sent in the meantime.
+func (s *Scheduler) takeOver() {
+	if err := s.repo.ReloadJobs(); err != nil {
+		slog.Error("Failed to reload job state", "error", err)
 	}
-
-	// Scoreboard - Monday, Tuesday, Friday 7:30 CDT
-	_, err = s.s.NewJob(
-		gocron.WeeklyJob(1, gocron.NewWeekdays(time.Monday, time.Tuesday, time.Friday), gocron.NewAtTimes(gocron.NewAtTime(7, 30, 0))),
-		gocron.NewTask(s.sendScoreboard),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create scoreboard job: %w", err)
+	if err := s.repo.ReloadOutbound(); err != nil {
+		slog.Error("Failed to reload outbound messages", "error", err)
 	}
-
-	// Trophies - Tuesday 7:30 CDT
-	_, err = s.s.NewJob(
-		gocron.WeeklyJob(1, gocron.NewWeekdays(time.Tuesday), gocron.NewAtTimes(gocron.NewAtTime(7, 30, 0))),
-		gocron.NewTask(s.sendTrophies),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create trophies job: %w", err)
+	if err := s.repo.ReloadWatchlists(); err != nil {
+		slog.Error("Failed to reload watchlists", "error", err)
 	}
-
-	// Current standings - Wednesday 7:30 CDT
-	_, err = s.s.NewJob(
-		gocron.WeeklyJob(1, gocron.NewWeekdays(time.Wednesday), gocron.NewAtTimes(gocron.NewAtTime(7, 30, 0))),
-		gocron.NewTask(s.sendStandings),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create standings job: %w", err)
+	if err := s.repo.ReloadResults(); err != nil {
+		slog.Error("Failed to reload matchup results", "error", err)
+	}
+	if err := s.repo.ReloadAnnouncedScores(); err != nil {
+		slog.Error("Failed to reload announced scores", "error", err)
+	}
+	if err := s.repo.ReloadSeenTransactions(); err != nil {
+		slog.Error("Failed to reload seen transactions", "error", err)
+	}
+	if err := s.repo.ReloadAlertChats(); err != nil {
+		slog.Error("Failed to reload alert chats", "error", err)
 	}
+	// Elections happen inside gocron's executor, which mustn't wait on
+	// adding jobs.
+	go s.catchUp()
+}
 
-	// Matchups - Thursday 19:30 EDT (18:30 CDT)
-	_, err = s.s.NewJob(
-		gocron.WeeklyJob(1, gocron.NewWeekdays(time.Thursday), gocron.NewAtTimes(gocron.NewAtTime(18, 30, 0))),
-		gocron.NewTask(s.sendMatchups),
-	)
+// Start schedules the report jobs and checks the schedule file for changes.
+// If this instance is the leader, runs missed while the bot was down are
+// made up; a standby does that when it takes over.
+func (s *Scheduler) Start() error {
+	s.mu.Lock()
+	err := s.addJobs(s.schedule)
+	s.mu.Unlock()
 	if err != nil {
-		return fmt.Errorf("failed to create matchups job: %w", err)
+		return err
 	}
 
-	// Players to Monitor report - Sunday 7:30 CDT
-	_, err = s.s.NewJob(
-		gocron.WeeklyJob(1, gocron.NewWeekdays(time.Sunday), gocron.NewAtTimes(gocron.NewAtTime(7, 30, 0))),
-		gocron.NewTask(s.sendPlayersToMonitor),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create players to monitor job: %w", err)
+	s.planGameTimes()
+	if err := s.elector.IsLeader(context.Background()); err != nil {
+		slog.Info("Starting as standby", "reason", err)
 	}
 
-	// Scoreboard - Sunday 16:00 and 20:00 EDT (15:00 and 19:00 CDT)
-	_, err = s.s.NewJob(
-		gocron.WeeklyJob(1, gocron.NewWeekdays(time.Sunday), gocron.NewAtTimes(gocron.NewAtTime(15, 0, 0), gocron.NewAtTime(19, 0, 0))),
-		gocron.NewTask(s.sendScoreboard),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create Sunday scoreboard job: %w", err)
+	if err := s.Every("reload schedule", s.cfg.ReloadInterval, s.reloadIfChanged); err != nil {
+		return err
+	}
+	if err := s.Every("plan game times", gameTimePlanInterval, s.planGameTimes); err != nil {
+		return err
 	}
 
 	s.s.Start()
+
+	s.mu.Lock()
+	s.running = true
+	s.mu.Unlock()
 	return nil
 }
 
-func (s *Scheduler) Stop() error {
-	return s.s.Shutdown()
+func (s *Scheduler) addJobs(schedule Schedule) error {
+	for _, job := range schedule.Jobs {
+		if !job.enabled() || len(job.Triggers) > 0 {
+			continue
+		}
+
+		crontabs, err := job.crontabs(schedule.Timezone)
+		if err != nil {
+			return err
+		}
+
+		for _, crontab := range crontabs {
+			_, err := s.s.NewJob(
+				gocron.CronJob(crontab, false),
+				gocron.NewTask(func() {
+					s.runJob(job, time.Now().Truncate(time.Minute))
+				}),
+				gocron.WithName(job.Name),
+				gocron.WithTags(scheduleTag, job.Name),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create %s job: %w", job.Name, err)
+			}
+		}
+	}
+	return nil
 }
 
-func (s *Scheduler) sendCloseScores() {
-	report, err := s.fantasyService.GetMondayNightCloseGames()
+// planGameTimes schedules a one-off run for each upcoming trigger of each
+// job, replacing the runs planned before.
+func (s *Scheduler) planGameTimes() {
+	s.mu.Lock()
+	hasTriggers := slices.ContainsFunc(s.schedule.Jobs, func(job Job) bool {
+		return job.enabled() && len(job.Triggers) > 0
+	})
+	s.mu.Unlock()
+
+	if !hasTriggers {
+		s.s.RemoveByTags(gameTimeTag)
+		return
+	}
+
+	weeks, err := s.fantasyService.GetNearbyProGames()
 	if err != nil {
-		slog.Error("Failed to get close games", "error", err)
+		slog.Error("Failed to plan game-time jobs", "error", err)
 		return
 	}
 
-	slog.Info("Sending close games", "time", time.Now().Format(time.RFC3339))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.s.RemoveByTags(gameTimeTag)
+	now := time.Now()
+	for _, job := range s.schedule.Jobs {
+		if !job.enabled() {
+			continue
+		}
+
+		planned := make(map[time.Time]bool)
+		for _, games := range weeks {
+			for _, trigger := range job.Triggers {
+				at, ok := trigger.at(games, s.eastern)
+				if !ok || !at.After(now) || planned[at] {
+					continue
+				}
+				planned[at] = true
+
+				_, err := s.s.NewJob(
+					gocron.OneTimeJob(gocron.OneTimeJobStartDateTime(at)),
+					gocron.NewTask(s.runJob, job, at),
+					gocron.WithName(job.Name),
+					gocron.WithTags(gameTimeTag, job.Name),
+				)
+				if err != nil {
+					slog.Error("Failed to plan game-time job", "job", job.Name, "at", at, "error", err)
+				}
+			}
+		}
+	}
+}
 
-	err = s.sendMessage(report)
-	if err != nil {
-		slog.Error("Failed to send close games", "error", err)
+// Reload reads the schedule file again and replaces the report jobs. The
+// current jobs are kept if the file is invalid.
+func (s *Scheduler) Reload() error {
+	if err := s.replaceJobs(); err != nil {
+		return err
 	}
+	s.planGameTimes()
+	return nil
 }
 
-func (s *Scheduler) sendScoreboard() {
-	scores, err := s.fantasyService.GetCurrentScores()
+func (s *Scheduler) replaceJobs() error {
+	schedule, err := loadSchedule(s.cfg.File)
 	if err != nil {
-		slog.Error("Failed to get current scores", "error", err)
-		return
+		return err
 	}
 
-	slog.Info("Sending scoreboard", "time", time.Now().Format(time.RFC3339))
-
-	err = s.sendMessage(scores)
-	if err != nil {
-		slog.Error("Failed to send scoreboard", "error", err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.s.RemoveByTags(scheduleTag)
+	if err := s.addJobs(schedule); err != nil {
+		// The schedule validated, so this only fails if gocron does;
+		// fall back to what was running.
+		s.s.RemoveByTags(scheduleTag)
+		if restoreErr := s.addJobs(s.schedule); restoreErr != nil {
+			slog.Error("Failed to restore schedule", "error", restoreErr)
+		}
+		return err
 	}
+
+	s.schedule = schedule
+	s.modTime = modTime(s.cfg.File)
+	slog.Info("Schedule reloaded", "jobs", len(schedule.Jobs))
+	return nil
 }
 
-func (s *Scheduler) sendTrophies() {
-	report, err := s.fantasyService.GetFinalScoreReport()
-	if err != nil {
-		slog.Error("Failed to get final score report", "error", err)
+func (s *Scheduler) reloadIfChanged() {
+	s.mu.Lock()
+	changed := !modTime(s.cfg.File).Equal(s.modTime)
+	s.mu.Unlock()
+
+	if !changed {
 		return
 	}
+	if err := s.Reload(); err != nil {
+		slog.Error("Failed to reload schedule", "path", s.cfg.File, "error", err)
+	}
+}
 
-	slog.Info("Sending trophies", "time", time.Now().Format(time.RFC3339))
-
-	err = s.sendMessage(report)
+// modTime returns the file's modification time, or the zero time if it
+// doesn't exist.
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
 	if err != nil {
-		slog.Error("Failed to send trophies", "error", err)
+		return time.Time{}
 	}
+	return info.ModTime()
 }
 
-func (s *Scheduler) sendStandings() {
-	standings, err := s.fantasyService.GetStandings()
+// Jobs lists the report jobs in schedule order, with the report each one
+// posts in the current season phase.
+func (s *Scheduler) Jobs() []models.JobStatus {
+	s.mu.Lock()
+	schedule := s.schedule
+	s.mu.Unlock()
+
+	phase, err := s.fantasyService.GetSeasonPhase()
 	if err != nil {
-		slog.Error("Failed to get standings", "error", err)
-		return
+		slog.Error("Failed to get season phase", "error", err)
 	}
 
-	slog.Info("Sending standings", "time", time.Now().Format(time.RFC3339))
+	nextRuns := make(map[string]time.Time)
+	for _, j := range s.s.Jobs() {
+		next, err := j.NextRun()
+		if err != nil || next.IsZero() {
+			continue
+		}
+		if current, ok := nextRuns[j.Name()]; !ok || next.Before(current) {
+			nextRuns[j.Name()] = next
+		}
+	}
 
-	err = s.sendMessage(standings)
-	if err != nil {
-		slog.Error("Failed to send standings", "error", err)
+	statuses := make([]models.JobStatus, 0, len(schedule.Jobs))
+	for _, job := range schedule.Jobs {
+		timezone := schedule.Timezone
+		if job.Timezone != "" {
+			timezone = job.Timezone
+		}
+		when := fmt.Sprintf("%s %s %s", strings.Join(job.Days, ", "), strings.Join(job.Times, ", "), timezone)
+		if len(job.Triggers) > 0 {
+			when = describeTriggers(job.Triggers)
+		}
+
+		report, inPhase := job.Report, true
+		if phase != "" {
+			report, inPhase = job.reportFor(phase)
+			if !inPhase {
+				report = job.Report
+			}
+		}
+
+		lastRun, _ := s.repo.GetLastJobRun(job.Name)
+		statuses = append(statuses, models.JobStatus{
+			Name:     job.Name,
+			Report:   report,
+			Schedule: when,
+			Phases:   job.phases(),
+			InPhase:  inPhase,
+			Enabled:  job.enabled(),
+			Paused:   s.repo.IsJobPaused(job.Name),
+			Approve:  job.Approve,
+			NextRun:  nextRuns[job.Name],
+			LastRun:  lastRun,
+		})
 	}
+	return statuses
 }
 
-func (s *Scheduler) sendMatchups() {
-	matchups, err := s.fantasyService.GetMatchups()
-	if err != nil {
-		slog.Error("Failed to get matchups", "error", err)
-		return
+func (s *Scheduler) findJob(name string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, job := range s.schedule.Jobs {
+		if job.Name == name {
+			return job, nil
+		}
 	}
+	return Job{}, fmt.Errorf("no job named %s", name)
+}
 
-	slog.Info("Sending matchups", "time", time.Now().Format(time.RFC3339))
+// Pause stops a job from posting until it is resumed. Paused jobs stay
+// paused across schedule reloads.
+func (s *Scheduler) Pause(name string) error {
+	if _, err := s.findJob(name); err != nil {
+		return err
+	}
+	s.repo.SetJobPaused(name, true)
+	return nil
+}
 
-	err = s.sendMessage(matchups)
-	if err != nil {
-		slog.Error("Failed to send matchups", "error", err)
+func (s *Scheduler) Resume(name string) error {
+	if _, err := s.findJob(name); err != nil {
+		return err
 	}
+	s.repo.SetJobPaused(name, false)
+	return nil
 }
 
-func (s *Scheduler) sendPlayersToMonitor() {
-	report, err := s.fantasyService.GetPlayersToMonitor()
+// Run posts a job's report now, even if it is paused, disabled, out of
+// season or needs approval. The report is still swapped for the current
+// phase.
+func (s *Scheduler) Run(name string) error {
+	job, err := s.findJob(name)
 	if err != nil {
-		slog.Error("Failed to get players to monitor", "error", err)
-		return
+		return err
 	}
 
-	slog.Info("Sending players to monitor", "time", time.Now().Format(time.RFC3339))
+	report := job.Report
+	if phase, err := s.fantasyService.GetSeasonPhase(); err != nil {
+		slog.Error("Failed to get season phase", "job", job.Name, "error", err)
+	} else if phaseReport, ok := job.reportFor(phase); ok {
+		report = phaseReport
+	}
+	_, err = s.post(job, report, job.Chats, time.Now().Truncate(time.Minute))
+	return err
+}
 
-	err = s.sendMessage(report)
+// Every runs task at a fixed interval. A run that is still going when the
+// next one is due delays it rather than overlapping.
+func (s *Scheduler) Every(name string, interval time.Duration, task func()) error {
+	_, err := s.s.NewJob(
+		gocron.DurationJob(interval),
+		gocron.NewTask(task),
+		gocron.WithName(name),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	)
 	if err != nil {
-		slog.Error("Failed to send players to monitor", "error", err)
+		return fmt.Errorf("failed to create %s job: %w", name, err)
+	}
+	return nil
+}
+
+// Location is the time zone scheduled jobs run in.
+func (s *Scheduler) Location() *time.Location {
+	return s.location
+}
+
+func (s *Scheduler) Stop() error {
+	s.mu.Lock()
+	s.running = false
+	s.mu.Unlock()
+	return s.s.Shutdown()
+}
+
+// Health reports whether the scheduler is running. A standby runs it too but
+// leaves the jobs to the leader.
+func (s *Scheduler) Health(context.Context) health.Result {
+	s.mu.Lock()
+	running := s.running
+	s.mu.Unlock()
+
+	if !running {
+		return health.Failing("scheduler isn't running")
+	}
+	if !s.elector.Leading() {
+		return health.Standby("another instance runs the jobs")
 	}
+	return health.OK(fmt.Sprintf("%d jobs scheduled", len(s.s.Jobs())))
 }
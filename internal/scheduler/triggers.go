@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// Trigger runs a job relative to the NFL schedule of a scoring period
+// instead of at fixed times, e.g. {"event": "first_kickoff", "offset": "-30m"}.
+type Trigger struct {
+	Event  string `json:"event"`
+	Offset string `json:"offset,omitempty"`
+}
+
+const (
+	eventFirstKickoff  = "first_kickoff"
+	eventEarlyGamesEnd = "early_games_end"
+	eventLateGamesEnd  = "late_games_end"
+	eventLastKickoff   = "last_kickoff"
+	eventLastGameEnd   = "last_game_end"
+)
+
+var eventLabels = map[string]string{
+	eventFirstKickoff:  "first kickoff",
+	eventEarlyGamesEnd: "end of the early games",
+	eventLateGamesEnd:  "end of the late games",
+	eventLastKickoff:   "final kickoff",
+	eventLastGameEnd:   "end of the final game",
+}
+
+// gameLength is roughly how long an NFL game takes. ESPN only lists kickoff
+// times, so game ends are estimated from it.
+const gameLength = 3*time.Hour + 15*time.Minute
+
+// Sunday windows are in Eastern time: early games kick off before 16:00,
+// late games before 20:00, and the night game after that.
+const (
+	lateWindowHour  = 16
+	nightWindowHour = 20
+)
+
+func (t Trigger) offset() (time.Duration, error) {
+	if t.Offset == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(t.Offset)
+}
+
+func (t Trigger) validate() error {
+	if _, ok := eventLabels[t.Event]; !ok {
+		return fmt.Errorf("unknown event %q", t.Event)
+	}
+	if _, err := t.offset(); err != nil {
+		return fmt.Errorf("offset %q is not a duration", t.Offset)
+	}
+	return nil
+}
+
+func (t Trigger) String() string {
+	offset, _ := t.offset()
+	amount := strings.TrimLeft(t.Offset, "+-")
+	switch {
+	case offset < 0:
+		return fmt.Sprintf("%s before %s", amount, eventLabels[t.Event])
+	case offset > 0:
+		return fmt.Sprintf("%s after %s", amount, eventLabels[t.Event])
+	default:
+		return "at " + eventLabels[t.Event]
+	}
+}
+
+// at returns when the trigger fires for a scoring period's games, ordered by
+// kickoff, or false if the period has no such event. Games whose kickoff
+// hasn't been set yet are left out.
+func (t Trigger) at(games []models.ProGame, eastern *time.Location) (time.Time, bool) {
+	var scheduled []models.ProGame
+	for _, game := range games {
+		if !game.StartTimeTBD {
+			scheduled = append(scheduled, game)
+		}
+	}
+	if len(scheduled) == 0 {
+		return time.Time{}, false
+	}
+
+	var at time.Time
+	switch t.Event {
+	case eventFirstKickoff:
+		at = scheduled[0].Kickoff
+	case eventLastKickoff:
+		at = scheduled[len(scheduled)-1].Kickoff
+	case eventLastGameEnd:
+		at = scheduled[len(scheduled)-1].Kickoff.Add(gameLength)
+	case eventEarlyGamesEnd, eventLateGamesEnd:
+		from, to := 0, lateWindowHour
+		if t.Event == eventLateGamesEnd {
+			from, to = lateWindowHour, nightWindowHour
+		}
+		kickoff, ok := lastSundayKickoff(scheduled, eastern, from, to)
+		if !ok {
+			return time.Time{}, false
+		}
+		at = kickoff.Add(gameLength)
+	default:
+		return time.Time{}, false
+	}
+
+	offset, _ := t.offset()
+	return at.Add(offset), true
+}
+
+// lastSundayKickoff returns the latest Sunday kickoff between the given
+// Eastern hours.
+func lastSundayKickoff(games []models.ProGame, eastern *time.Location, from, to int) (time.Time, bool) {
+	var last time.Time
+	for _, game := range games {
+		kickoff := game.Kickoff.In(eastern)
+		if kickoff.Weekday() != time.Sunday || kickoff.Hour() < from || kickoff.Hour() >= to {
+			continue
+		}
+		if kickoff.After(last) {
+			last = kickoff
+		}
+	}
+	return last, !last.IsZero()
+}
+
+func describeTriggers(triggers []Trigger) string {
+	descriptions := make([]string, len(triggers))
+	for i, trigger := range triggers {
+		descriptions[i] = trigger.String()
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// lastTriggered returns the most recent time at or before now that one of
+// the triggers fired.
+func lastTriggered(triggers []Trigger, weeks [][]models.ProGame, now time.Time, eastern *time.Location) (time.Time, bool) {
+	var last time.Time
+	for _, games := range weeks {
+		for _, trigger := range triggers {
+			at, ok := trigger.at(games, eastern)
+			if ok && !at.After(now) && at.After(last) {
+				last = at
+			}
+		}
+	}
+	return last, !last.IsZero()
+}
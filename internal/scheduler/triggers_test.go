@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+func loadEastern(t *testing.T) *time.Location {
+	t.Helper()
+	eastern, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("loading Eastern time: %v", err)
+	}
+	return eastern
+}
+
+// TestTriggerAt checks the game-time events against the weeks where the
+// NFL schedule thins out: bye weeks without a late window or a Monday game,
+// late-season Saturday games and the week with no games at all.
+func TestTriggerAt(t *testing.T) {
+	eastern := loadEastern(t)
+	// at is a December 2025 day and time in Eastern time.
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2025, time.December, day, hour, min, 0, 0, eastern)
+	}
+	// ESPN lists kickoffs in UTC.
+	week := func(kickoffs ...time.Time) []models.ProGame {
+		games := make([]models.ProGame, len(kickoffs))
+		for i, kickoff := range kickoffs {
+			games[i] = models.ProGame{ID: i + 1, Kickoff: kickoff.UTC()}
+		}
+		return games
+	}
+
+	full := week(at(4, 20, 15), at(7, 13, 0), at(7, 16, 25), at(7, 20, 20), at(8, 20, 15))
+	// Six teams on bye: no Sunday late games and no Monday night game.
+	bye := week(at(4, 20, 15), at(7, 13, 0), at(7, 13, 0), at(7, 20, 20))
+	// Week 16 moves games to Saturday, whose late kickoffs aren't Sunday's.
+	saturday := week(at(18, 20, 15), at(20, 16, 30), at(20, 20, 0), at(21, 13, 0), at(22, 20, 15))
+	// A flexed game whose kickoff isn't set yet.
+	flexed := append(week(at(4, 20, 15), at(7, 13, 0)), models.ProGame{ID: 3, Kickoff: at(7, 23, 0), StartTimeTBD: true})
+
+	tests := []struct {
+		name    string
+		games   []models.ProGame
+		trigger Trigger
+		want    time.Time
+		ok      bool
+	}{
+		{name: "late games end", games: full, trigger: Trigger{Event: eventLateGamesEnd}, want: at(7, 16, 25).Add(gameLength), ok: true},
+		{name: "last kickoff is Monday night", games: full, trigger: Trigger{Event: eventLastKickoff}, want: at(8, 20, 15), ok: true},
+		{name: "bye week has no late games", games: bye, trigger: Trigger{Event: eventLateGamesEnd}},
+		{name: "bye week early games still end", games: bye, trigger: Trigger{Event: eventEarlyGamesEnd, Offset: "30m"}, want: at(7, 13, 0).Add(gameLength + 30*time.Minute), ok: true},
+		{name: "bye week ends on Sunday night", games: bye, trigger: Trigger{Event: eventLastGameEnd}, want: at(7, 20, 20).Add(gameLength), ok: true},
+		{name: "Saturday games aren't Sunday's late games", games: saturday, trigger: Trigger{Event: eventLateGamesEnd}},
+		{name: "Saturday games count for first kickoff", games: saturday, trigger: Trigger{Event: eventFirstKickoff, Offset: "-30m"}, want: at(18, 19, 45), ok: true},
+		{name: "flexed game without a kickoff is left out", games: flexed, trigger: Trigger{Event: eventLastKickoff}, want: at(7, 13, 0), ok: true},
+		{name: "week without games", trigger: Trigger{Event: eventFirstKickoff}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.trigger.at(tt.games, eastern)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("at = %v, want %v", got.In(eastern), tt.want)
+			}
+		})
+	}
+}
+
+func TestLastTriggeredSkipsByeWeekEvents(t *testing.T) {
+	eastern := loadEastern(t)
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2025, time.November, day, hour, min, 0, 0, eastern)
+	}
+
+	weeks := [][]models.ProGame{
+		// The previous week had late games.
+		{{Kickoff: at(23, 13, 0).UTC()}, {Kickoff: at(23, 16, 25).UTC()}},
+		// This week is a bye week without any.
+		{{Kickoff: at(30, 13, 0).UTC()}},
+	}
+	triggers := []Trigger{{Event: eventLateGamesEnd}}
+
+	got, ok := lastTriggered(triggers, weeks, at(30, 23, 0), eastern)
+	if !ok || !got.Equal(at(23, 16, 25).Add(gameLength)) {
+		t.Errorf("lastTriggered = %v, %v, want the previous week's late games", got.In(eastern), ok)
+	}
+}
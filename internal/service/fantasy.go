@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"log/slog"
 	"math"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/omarshaarawi/coachbot/internal/api/fantasy"
+	"github.com/omarshaarawi/coachbot/internal/metrics"
 	"github.com/omarshaarawi/coachbot/internal/models"
 	"github.com/omarshaarawi/coachbot/internal/repository/memory"
 )
@@ -32,9 +34,18 @@ func (s *FantasyService) GetCurrentWeek() (int, error) {
 	return metadata.CurrentWeek, nil
 }
 
+// Cached league metadata is refetched once a day, or every hour when the
+// season phase could change soon, so jobs don't run in the wrong phase for a
+// day after the draft or the end of the regular season or playoffs.
+const (
+	metadataTTL         = 24 * time.Hour
+	metadataBoundaryTTL = time.Hour
+)
+
 func (s *FantasyService) getLeagueMetadata() (*models.LeagueMetadata, error) {
 	metadata := s.repo.GetMetadata()
-	if metadata == nil || time.Since(metadata.LastUpdated) > 24*time.Hour {
+	if metadata == nil || time.Since(metadata.LastUpdated) > metadataMaxAge(metadata) {
+		metrics.CacheLookups.WithLabelValues("league_metadata", "miss").Inc()
 		newMetadata, err := s.api.GetLeagueMetadata()
 		if err != nil {
 			return nil, err
@@ -42,56 +53,63 @@ func (s *FantasyService) getLeagueMetadata() (*models.LeagueMetadata, error) {
 		s.repo.SaveMetadata(newMetadata)
 		return newMetadata, nil
 	}
+	metrics.CacheLookups.WithLabelValues("league_metadata", "hit").Inc()
 	return metadata, nil
 }
 
-func (s *FantasyService) GetStandings() (string, error) {
-	standings, err := s.api.GetStandings()
+// scoringSettings returns the league's scoring from the cached metadata, or
+// none if it can't be fetched, which leaves point breakdowns out.
+func (s *FantasyService) scoringSettings() models.ScoringSettings {
+	metadata, err := s.getLeagueMetadata()
 	if err != nil {
-		return "", fmt.Errorf("error fetching standings: %w", err)
+		slog.Warn("Could not fetch scoring settings, leaving out point breakdowns", "error", err)
+		return models.ScoringSettings{}
 	}
+	return metadata.Scoring
+}
 
-	var sb strings.Builder
-	sb.WriteString("🏆 *Current Standings*\n\n")
-	for _, team := range standings {
-		sb.WriteString(fmt.Sprintf("%d. *%s*\n", team.Rank, team.TeamName))
-		sb.WriteString(fmt.Sprintf("   Record: %d-%d-%d\n", team.Wins, team.Losses, team.Ties))
-		sb.WriteString(fmt.Sprintf("   Points For: %.2f\n", team.PointsFor))
-		sb.WriteString(fmt.Sprintf("   Points Against: %.2f\n\n", team.PointsAgainst))
+// metadataMaxAge is how long metadata stays cached: shorter before the draft
+// and from the last week of the regular season on, when the phase is about
+// to change.
+func metadataMaxAge(metadata *models.LeagueMetadata) time.Duration {
+	if !metadata.DraftComplete ||
+		(metadata.RegularSeasonWeeks > 0 && metadata.CurrentWeek >= metadata.RegularSeasonWeeks) ||
+		metadata.CurrentScoringPeriod >= metadata.LastWeek {
+		return metadataBoundaryTTL
 	}
-
-	return sb.String(), nil
+	return metadataTTL
 }
 
-func (s *FantasyService) GetCurrentScores() (string, error) {
-	week, err := s.GetCurrentWeek()
+func (s *FantasyService) GetStandings() (models.StandingsReport, error) {
+	standings, err := s.api.GetStandings()
 	if err != nil {
-		return "", fmt.Errorf("error fetching current week: %w", err)
+		return models.StandingsReport{}, fmt.Errorf("error fetching standings: %w", err)
 	}
 
+	return models.StandingsReport{Standings: standings}, nil
+}
+
+// GetWeekScores returns the matchups of any week with team names filled in.
+func (s *FantasyService) GetWeekScores(week int) ([]models.Matchup, error) {
 	scores, err := s.api.GetCurrentScores(week)
 	if err != nil {
-		return "", fmt.Errorf("error fetching current scores: %w", err)
+		return nil, fmt.Errorf("error fetching week %d scores: %w", week, err)
 	}
+	return withTeamNames(scores), nil
+}
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("🏈 *Week %d Current Scores*\n\n", week))
-
-	for _, score := range scores {
-		homeTeam := getTeamName(score.HomeTeamID)
-		awayTeam := getTeamName(score.AwayTeamID)
-
-		sb.WriteString(fmt.Sprintf("*%s* vs *%s*\n", homeTeam, awayTeam))
-		sb.WriteString(fmt.Sprintf("Current: %.2f - %.2f\n", score.HomeScore, score.AwayScore))
-		sb.WriteString(fmt.Sprintf("Projected: %.2f - %.2f\n", score.HomeProjected, score.AwayProjected))
+func (s *FantasyService) GetCurrentScores() (models.ScoresReport, error) {
+	week, err := s.GetCurrentWeek()
+	if err != nil {
+		return models.ScoresReport{}, fmt.Errorf("error fetching current week: %w", err)
+	}
 
-		if score.IsCompleted {
-			sb.WriteString("(Final)\n")
-		}
-		sb.WriteString("\n")
+	scores, err := s.api.GetCurrentScores(week)
+	if err != nil {
+		return models.ScoresReport{}, fmt.Errorf("error fetching current scores: %w", err)
 	}
 
-	return sb.String(), nil
+	return models.ScoresReport{Week: week, Matchups: withTeamNames(scores)}, nil
 }
 
 var teamIDToName = map[int]string{
@@ -111,168 +129,314 @@ func getTeamName(teamID int) string {
 	return name
 }
 
-func (s *FantasyService) WhoHas(playerName string) (string, error) {
+func withTeamNames(scores []models.Matchup) []models.Matchup {
+	for i := range scores {
+		scores[i].HomeTeam = getTeamName(scores[i].HomeTeamID)
+		scores[i].AwayTeam = getTeamName(scores[i].AwayTeamID)
+	}
+	return scores
+}
+
+// Choice is one option the user can pick when a lookup is ambiguous.
+type Choice struct {
+	ID    int
+	Label string
+}
+
+// AmbiguousMatchError is returned when a player or team search matches
+// several candidates and the user has to pick one of Choices.
+type AmbiguousMatchError struct {
+	Kind    string // "player" or "team"
+	Query   string
+	Choices []Choice
+}
+
+func (e *AmbiguousMatchError) Error() string {
+	return fmt.Sprintf("%d %ss match '%s'", len(e.Choices), e.Kind, e.Query)
+}
+
+func (s *FantasyService) WhoHas(playerName string) (models.WhoHasResult, error) {
 	week, err := s.GetCurrentWeek()
 	if err != nil {
-		return "", fmt.Errorf("error fetching current week: %w", err)
+		return models.WhoHasResult{}, fmt.Errorf("error fetching current week: %w", err)
 	}
 
 	result, err := s.api.WhoHas(playerName, week)
 	if err != nil {
-		return "", fmt.Errorf("error checking who has player: %w", err)
+		return models.WhoHasResult{}, fmt.Errorf("error checking who has player: %w", err)
+	}
+
+	if len(result.Candidates) > 0 {
+		ambiguous := &AmbiguousMatchError{Kind: "player", Query: playerName}
+		for _, c := range result.Candidates {
+			owner := c.TeamName
+			if owner == "Unknown" {
+				owner = "FA"
+			}
+			ambiguous.Choices = append(ambiguous.Choices, Choice{
+				ID:    c.PlayerID,
+				Label: fmt.Sprintf("%s (%s - %s) · %s", c.Name, c.Position, c.ProTeam, owner),
+			})
+		}
+		return models.WhoHasResult{}, ambiguous
 	}
 
 	if !result.Found {
-		return fmt.Sprintf("🔍 No player found matching '%s'.", playerName), nil
+		result.PlayerName = playerName
 	}
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("*%s* (%s - %s)\n", result.PlayerName, result.Position, result.ProTeam))
-	sb.WriteString("━━━━━━━━━━━━━━━━\n")
+	return result, nil
+}
 
-	if result.TeamID != 0 {
-		sb.WriteString(fmt.Sprintf("*%s*\n", result.TeamName))
-		if result.LineupSlot == "Bench" || result.LineupSlot == "IR" {
-			sb.WriteString(fmt.Sprintf("%s\n", result.LineupSlot))
-		} else {
-			sb.WriteString("Starting\n")
-		}
-	} else {
-		sb.WriteString("Free Agent\n")
+// WhoHasPlayer reports on a specific player picked from an ambiguous search.
+func (s *FantasyService) WhoHasPlayer(playerID int) (models.WhoHasResult, error) {
+	week, err := s.GetCurrentWeek()
+	if err != nil {
+		return models.WhoHasResult{}, fmt.Errorf("error fetching current week: %w", err)
 	}
 
-	var pointsStr string
-	if !result.IsProjected {
-		pointsStr = fmt.Sprintf("%.2f", result.Points)
-		sb.WriteString(fmt.Sprintf("\n%s pts (Actual)", pointsStr))
-	} else if result.Points > 0 {
-		pointsStr = fmt.Sprintf("%.2f", result.Points)
-		sb.WriteString(fmt.Sprintf("\n%s pts (Projected)", pointsStr))
-	} else {
-		sb.WriteString("\nTBD pts")
+	result, err := s.api.WhoHasPlayer(playerID, week)
+	if err != nil {
+		return models.WhoHasResult{}, fmt.Errorf("error checking who has player: %w", err)
+	}
+
+	if !result.Found {
+		result.PlayerName = fmt.Sprintf("player #%d", playerID)
 	}
 
-	sb.WriteString(fmt.Sprintf("\n%0.1f%% Rostered", result.PercentOwned))
+	return result, nil
+}
 
-	return sb.String(), nil
+// PlayerCard is a self-contained player summary, used for inline query results.
+type PlayerCard struct {
+	PlayerID    int
+	Title       string
+	Description string
+	Result      models.WhoHasResult
 }
 
-func (s *FantasyService) GetPlayersToMonitor() (string, error) {
+// maxPlayerCards is how many results an inline query returns.
+const maxPlayerCards = 10
+
+// SearchPlayerCards returns player cards for every player matching query,
+// using the same data as WhoHas.
+func (s *FantasyService) SearchPlayerCards(query string) ([]PlayerCard, error) {
 	week, err := s.GetCurrentWeek()
 	if err != nil {
-		return "", fmt.Errorf("error fetching current week: %w", err)
+		return nil, fmt.Errorf("error fetching current week: %w", err)
 	}
 
-	report, err := s.api.GetPlayersToMonitor(week)
+	results, err := s.api.SearchPlayers(query, week, maxPlayerCards)
 	if err != nil {
-		return "", fmt.Errorf("error fetching players to monitor: %w", err)
+		return nil, fmt.Errorf("error searching players: %w", err)
 	}
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("🚑 *Week %d Players to Monitor*\n\n", week))
+	cards := make([]PlayerCard, len(results))
+	for i, result := range results {
+		owner := "Free Agent"
+		if result.TeamID != 0 {
+			owner = result.TeamName
+		}
 
-	if len(report.Teams) == 0 {
-		sb.WriteString("No players to monitor at this time.")
-		return sb.String(), nil
-	}
+		points := "TBD pts"
+		if !result.IsProjected {
+			points = fmt.Sprintf("%.2f pts", result.Points)
+		} else if result.Points > 0 {
+			points = fmt.Sprintf("%.2f proj", result.Points)
+		}
 
-	for _, team := range report.Teams {
-		sb.WriteString(fmt.Sprintf("*%s:*\n", team.TeamName))
-		for _, player := range team.Players {
-			sb.WriteString(fmt.Sprintf("  • %s %s - %s\n", player.Position, player.Name, player.InjuryStatus))
+		cards[i] = PlayerCard{
+			PlayerID:    result.PlayerID,
+			Title:       fmt.Sprintf("%s (%s - %s)", result.PlayerName, result.Position, result.ProTeam),
+			Description: fmt.Sprintf("%s · %0.1f%% rostered · %s", owner, result.PercentOwned, points),
+			Result:      result,
 		}
-		sb.WriteString("\n")
 	}
 
-	return sb.String(), nil
+	return cards, nil
 }
 
-func (s *FantasyService) GetFinalScoreReport() (string, error) {
+func (s *FantasyService) GetPlayersToMonitor() (models.PlayersToMonitorReport, error) {
 	week, err := s.GetCurrentWeek()
 	if err != nil {
-		return "", fmt.Errorf("error fetching current week: %w", err)
+		return models.PlayersToMonitorReport{}, fmt.Errorf("error fetching current week: %w", err)
 	}
 
-	currentScores, err := s.api.GetCurrentScores(week)
+	report, err := s.api.GetPlayersToMonitor(week)
 	if err != nil {
-		return "", fmt.Errorf("error fetching matchups: %w", err)
+		return models.PlayersToMonitorReport{}, fmt.Errorf("error fetching players to monitor: %w", err)
 	}
 
-	report := processScores(currentScores)
-	return formatFinalScoreReport(report), nil
+	report.Week = week
+	return report, nil
 }
 
-func (s *FantasyService) GetTeamRoster(teamName string) (string, error) {
+func (s *FantasyService) GetFinalScoreReport() (models.FinalScoreReport, error) {
 	week, err := s.GetCurrentWeek()
 	if err != nil {
-		return "", fmt.Errorf("error fetching current week: %w", err)
+		return models.FinalScoreReport{}, fmt.Errorf("error fetching current week: %w", err)
 	}
 
-	roster, err := s.api.GetTeamRoster(teamName, week)
+	currentScores, err := s.api.GetCurrentScores(week)
 	if err != nil {
-		return "", fmt.Errorf("error fetching team roster: %w", err)
+		return models.FinalScoreReport{}, fmt.Errorf("error fetching matchups: %w", err)
 	}
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("📋 *%s's Roster*\n\n", roster.TeamName))
+	report := processScores(currentScores)
+	report.Week = week
 
-	sb.WriteString("*Starting Lineup:*\n")
-	for _, player := range roster.Players {
-		if player.IsStarter {
-			pointsStr := player.PointsLabel
-			if pointsStr != "IR" && pointsStr != "BYE" {
-				pointsStr += " pts"
-			}
+	// A failed check shouldn't hold up the trophies.
+	discrepancies, err := s.api.ValidateScores(week, s.scoringSettings())
+	if err != nil {
+		slog.Warn("Could not validate final scores", "week", week, "error", err)
+	}
+	for _, d := range discrepancies {
+		slog.Warn("Final score differs from recalculated score", "week", week, "team", d.TeamName, "espn", d.ESPN, "calculated", d.Calculated)
+	}
+	report.Discrepancies = discrepancies
 
-			injuryStr := ""
-			if player.InjuryStatus != "" &&
-				player.InjuryStatus != "ACTIVE" &&
-				player.InjuryStatus != "INJURY_RESERVE" {
-				statusAbbr := map[string]string{
-					"QUESTIONABLE": "Q",
-					"DOUBTFUL":     "D",
-					"OUT":          "O",
-				}[player.InjuryStatus]
-				injuryStr = fmt.Sprintf(" (%s)", statusAbbr)
-			}
+	return report, nil
+}
+
+// RecordAnnouncedScores remembers the final scores posted for a week so later
+// stat corrections can be detected against them.
+func (s *FantasyService) RecordAnnouncedScores(report models.FinalScoreReport) {
+	s.repo.SaveAnnouncedScores(report)
+}
+
+// GetAnnouncedScores returns the final scores announced for a week, with any
+// stat corrections since applied.
+func (s *FantasyService) GetAnnouncedScores(week int) (models.FinalScoreReport, bool) {
+	return s.repo.GetAnnouncedScores(week)
+}
+
+// CheckStatCorrections re-fetches the most recently announced week and
+// compares it with what was announced. It returns false when nothing changed.
+// Once the report is posted, RecordAnnouncedScores with its corrected scores
+// makes sure each correction is only reported once.
+func (s *FantasyService) CheckStatCorrections() (models.StatCorrectionReport, bool, error) {
+	announced, ok := s.repo.GetLatestAnnouncedScores()
+	if !ok {
+		return models.StatCorrectionReport{}, false, nil
+	}
+
+	scores, err := s.api.GetCurrentScores(announced.Week)
+	if err != nil {
+		return models.StatCorrectionReport{}, false, fmt.Errorf("error fetching week %d scores: %w", announced.Week, err)
+	}
+
+	current := processScores(scores)
+	current.Week = announced.Week
+
+	report := diffFinalScores(announced, current)
+	if !report.HasChanges() {
+		return report, false, nil
+	}
+
+	report.Corrected = current
+	return report, true, nil
+}
+
+// scoreEpsilon absorbs float noise when comparing scores rounded to 2 decimals.
+const scoreEpsilon = 0.005
+
+func diffFinalScores(before, after models.FinalScoreReport) models.StatCorrectionReport {
+	report := models.StatCorrectionReport{Week: before.Week}
 
-			sb.WriteString(fmt.Sprintf("▫️ %s %s%s - %s\n",
-				player.Position,
-				player.Name,
-				injuryStr,
-				pointsStr))
+	previous := make(map[int]models.Matchup)
+	for _, m := range before.Matchups {
+		previous[m.MatchID] = m
+	}
+
+	for _, m := range after.Matchups {
+		old, ok := previous[m.MatchID]
+		if !ok {
+			continue
+		}
+
+		if math.Abs(old.HomeScore-m.HomeScore) > scoreEpsilon {
+			report.ScoreChanges = append(report.ScoreChanges, models.ScoreChange{Team: m.HomeTeam, Before: old.HomeScore, After: m.HomeScore})
+		}
+		if math.Abs(old.AwayScore-m.AwayScore) > scoreEpsilon {
+			report.ScoreChanges = append(report.ScoreChanges, models.ScoreChange{Team: m.AwayTeam, Before: old.AwayScore, After: m.AwayScore})
+		}
+
+		if oldWinner, newWinner := matchupWinner(old), matchupWinner(m); oldWinner != newWinner {
+			report.FlippedResults = append(report.FlippedResults, models.ResultFlip{
+				HomeTeam:  m.HomeTeam,
+				AwayTeam:  m.AwayTeam,
+				HomeScore: m.HomeScore,
+				AwayScore: m.AwayScore,
+				OldWinner: oldWinner,
+				NewWinner: newWinner,
+			})
 		}
 	}
 
-	sb.WriteString("\n*Bench:*\n")
-	for _, player := range roster.Players {
-		if !player.IsStarter {
-			pointsStr := player.PointsLabel
-			if pointsStr != "IR" && pointsStr != "BYE" {
-				pointsStr += " pts"
-			}
+	awarded := make(map[string]models.Trophy)
+	for _, trophy := range before.Trophies {
+		awarded[trophy.Category] = trophy
+	}
+	for _, trophy := range after.Trophies {
+		old, ok := awarded[trophy.Category]
+		if !ok {
+			continue
+		}
+		if old.Team != trophy.Team || math.Abs(old.Value-trophy.Value) > scoreEpsilon {
+			report.TrophyChanges = append(report.TrophyChanges, models.TrophyChange{Before: old, After: trophy})
+		}
+	}
 
-			injuryStr := ""
-			if player.InjuryStatus != "" &&
-				player.InjuryStatus != "ACTIVE" &&
-				player.InjuryStatus != "INJURY_RESERVE" {
-				statusAbbr := map[string]string{
-					"QUESTIONABLE": "Q",
-					"DOUBTFUL":     "D",
-					"OUT":          "O",
-				}[player.InjuryStatus]
-				injuryStr = fmt.Sprintf(" (%s)", statusAbbr)
-			}
+	return report
+}
+
+// matchupWinner returns the winning team's name, or "Tie".
+func matchupWinner(m models.Matchup) string {
+	switch {
+	case m.HomeScore > m.AwayScore:
+		return m.HomeTeam
+	case m.AwayScore > m.HomeScore:
+		return m.AwayTeam
+	default:
+		return "Tie"
+	}
+}
+
+func (s *FantasyService) GetTeamRoster(teamName string) (models.TeamRoster, error) {
+	week, err := s.GetCurrentWeek()
+	if err != nil {
+		return models.TeamRoster{}, fmt.Errorf("error fetching current week: %w", err)
+	}
+
+	roster, err := s.api.GetTeamRoster(teamName, week, s.scoringSettings())
+	if err != nil {
+		return models.TeamRoster{}, fmt.Errorf("error fetching team roster: %w", err)
+	}
 
-			sb.WriteString(fmt.Sprintf("▫️ %s %s%s - %s\n",
-				player.Position,
-				player.Name,
-				injuryStr,
-				pointsStr))
+	if len(roster.Candidates) > 0 {
+		ambiguous := &AmbiguousMatchError{Kind: "team", Query: teamName}
+		for _, c := range roster.Candidates {
+			ambiguous.Choices = append(ambiguous.Choices, Choice{ID: c.TeamID, Label: c.TeamName})
 		}
+		return models.TeamRoster{}, ambiguous
+	}
+
+	return roster, nil
+}
+
+// GetTeamRosterByID reports the roster of a team picked from an ambiguous search.
+func (s *FantasyService) GetTeamRosterByID(teamID int) (models.TeamRoster, error) {
+	week, err := s.GetCurrentWeek()
+	if err != nil {
+		return models.TeamRoster{}, fmt.Errorf("error fetching current week: %w", err)
+	}
+
+	roster, err := s.api.GetTeamRosterByID(teamID, week, s.scoringSettings())
+	if err != nil {
+		return models.TeamRoster{}, fmt.Errorf("error fetching team roster: %w", err)
 	}
 
-	return sb.String(), nil
+	return roster, nil
 }
 
 func processScores(scores []models.Matchup) models.FinalScoreReport {
@@ -293,10 +457,13 @@ func processScores(scores []models.Matchup) models.FinalScoreReport {
 		awayTeam := getTeamName(score.AwayTeamID)
 
 		report.Matchups[i] = models.Matchup{
-			HomeTeam:  homeTeam,
-			AwayTeam:  awayTeam,
-			HomeScore: score.HomeScore,
-			AwayScore: score.AwayScore,
+			MatchID:    score.MatchID,
+			HomeTeamID: score.HomeTeamID,
+			AwayTeamID: score.AwayTeamID,
+			HomeTeam:   homeTeam,
+			AwayTeam:   awayTeam,
+			HomeScore:  score.HomeScore,
+			AwayScore:  score.AwayScore,
 		}
 
 		// High Score
@@ -346,54 +513,27 @@ func processScores(scores []models.Matchup) models.FinalScoreReport {
 		{Category: "Closest Win", Team: closestWinTeam, Value: closestWin},
 	}
 
-	return report
-}
-
-func formatFinalScoreReport(report models.FinalScoreReport) string {
-	var sb strings.Builder
-
-	sb.WriteString("📊 *Final Scores:*\n\n")
-
 	sort.Slice(report.Matchups, func(i, j int) bool {
 		totalScoreI := report.Matchups[i].HomeScore + report.Matchups[i].AwayScore
 		totalScoreJ := report.Matchups[j].HomeScore + report.Matchups[j].AwayScore
 		return totalScoreI > totalScoreJ
 	})
 
-	for _, m := range report.Matchups {
-		sb.WriteString(fmt.Sprintf("%s %.2f - %.2f %s\n", m.HomeTeam, m.HomeScore, m.AwayScore, m.AwayTeam))
-	}
-
-	sb.WriteString("\n🏆 *Trophies:*\n")
-	for _, t := range report.Trophies {
-		switch t.Category {
-		case "High Score":
-			sb.WriteString(fmt.Sprintf("Highest Score: %s (%.2f)\n", t.Team, t.Value))
-		case "Low Score":
-			sb.WriteString(fmt.Sprintf("Lowest Score: %s (%.2f)\n", t.Team, t.Value))
-		case "Biggest Win":
-			sb.WriteString(fmt.Sprintf("Biggest Win: %s (Margin: %.2f)\n", t.Team, t.Value))
-		case "Closest Win":
-			sb.WriteString(fmt.Sprintf("Closest Win: %s (Margin: %.2f)\n", t.Team, t.Value))
-		}
-	}
-
-	return sb.String()
+	return report
 }
 
-func (s *FantasyService) GetMondayNightCloseGames() (string, error) {
+func (s *FantasyService) GetMondayNightCloseGames() (models.CloseGamesReport, error) {
 	week, err := s.GetCurrentWeek()
 	if err != nil {
-		return "", fmt.Errorf("error fetching current week: %w", err)
+		return models.CloseGamesReport{}, fmt.Errorf("error fetching current week: %w", err)
 	}
 
 	currentScores, err := s.api.GetCurrentScores(week)
 	if err != nil {
-		return "", fmt.Errorf("error fetching current scores: %w", err)
+		return models.CloseGamesReport{}, fmt.Errorf("error fetching current scores: %w", err)
 	}
 
-	closeGames := findCloseGames(currentScores)
-	return formatMondayNightCloseGames(closeGames), nil
+	return models.CloseGamesReport{Games: findCloseGames(currentScores)}, nil
 }
 
 func findCloseGames(scores []models.Matchup) []models.CloseGame {
@@ -419,56 +559,504 @@ func findCloseGames(scores []models.Matchup) []models.CloseGame {
 	return closeGames
 }
 
-func formatMondayNightCloseGames(closeGames []models.CloseGame) string {
-	var sb strings.Builder
+func (s *FantasyService) GetMatchups() (models.MatchupsReport, error) {
+	week, err := s.GetCurrentWeek()
+	if err != nil {
+		return models.MatchupsReport{}, fmt.Errorf("error fetching current week: %w", err)
+	}
+
+	currentScores, err := s.api.GetCurrentScores(week)
+	if err != nil {
+		return models.MatchupsReport{}, fmt.Errorf("error fetching current scores: %w", err)
+	}
+
+	slog.Info("Matchups", "matchups", len(currentScores))
+	return models.MatchupsReport{Week: week, Matchups: withTeamNames(currentScores)}, nil
+}
+
+// GetSeasonPhase works out where the league is in its season from the league
+// settings: before the draft, the regular season, the playoffs or after the
+// final scoring period. The period right after the final one still counts as
+// the playoffs until the final week's results are posted, so the reports
+// that wrap up the championship week still go out.
+func (s *FantasyService) GetSeasonPhase() (models.SeasonPhase, error) {
+	metadata, err := s.getLeagueMetadata()
+	if err != nil {
+		return "", fmt.Errorf("error fetching league metadata: %w", err)
+	}
 
-	sb.WriteString("🏈 *Monday Night Watch List*\n\n")
+	finalDone := false
+	if metadata.IsActive && metadata.CurrentScoringPeriod == metadata.LastWeek+1 {
+		finalDone, err = s.finalWeekComplete(metadata.CurrentWeek)
+		if err != nil {
+			return "", fmt.Errorf("error fetching final week results: %w", err)
+		}
+	}
+	return seasonPhase(metadata, finalDone), nil
+}
 
-	if len(closeGames) == 0 {
-		sb.WriteString("No close games this week. All outcomes are likely decided.")
-		return sb.String()
+// finalWeekComplete reports whether every matchup of the final matchup
+// period has a winner.
+func (s *FantasyService) finalWeekComplete(week int) (bool, error) {
+	matchups, err := s.api.GetCurrentScores(week)
+	if err != nil {
+		return false, err
 	}
+	for _, m := range matchups {
+		if !m.IsCompleted {
+			return false, nil
+		}
+	}
+	return true, nil
+}
 
-	for _, game := range closeGames {
-		sb.WriteString(fmt.Sprintf("%s %.2f - %.2f %s (Margin: %.2f)\n",
-			game.HomeTeam, game.HomeScore, game.AwayScore, game.AwayTeam, game.Margin))
+// seasonPhase works out the phase from the league metadata. finalDone is
+// whether the final week's results are posted, which only matters in the
+// period right after the final one.
+func seasonPhase(metadata *models.LeagueMetadata, finalDone bool) models.SeasonPhase {
+	switch {
+	case !metadata.IsActive,
+		metadata.CurrentScoringPeriod > metadata.LastWeek+1,
+		metadata.CurrentScoringPeriod > metadata.LastWeek && finalDone:
+		return models.PhaseOffseason
+	case !metadata.DraftComplete:
+		return models.PhasePreseason
+	case metadata.RegularSeasonWeeks == 0 || metadata.CurrentWeek <= metadata.RegularSeasonWeeks:
+		return models.PhaseRegularSeason
+	default:
+		return models.PhasePlayoffs
 	}
+}
 
-	return sb.String()
+// playoffRounds is how many rounds it takes to get from teams down to a
+// champion.
+func playoffRounds(teams int) int {
+	rounds := 0
+	for n := 1; n < teams; n *= 2 {
+		rounds++
+	}
+	return rounds
 }
 
-func (s *FantasyService) GetMatchups() (string, error) {
+// GetPlayoffBracket returns the winners bracket, one round per matchup period
+// after the regular season. Rounds that haven't been set yet are empty.
+func (s *FantasyService) GetPlayoffBracket() (models.PlayoffBracketReport, error) {
+	metadata, err := s.getLeagueMetadata()
+	if err != nil {
+		return models.PlayoffBracketReport{}, fmt.Errorf("error fetching league metadata: %w", err)
+	}
+
+	rounds := playoffRounds(metadata.PlayoffTeams)
+	if metadata.RegularSeasonWeeks == 0 || rounds == 0 {
+		return models.PlayoffBracketReport{}, fmt.Errorf("league has no playoffs")
+	}
+
+	weeks := make([]int, rounds)
+	for i := range weeks {
+		weeks[i] = metadata.RegularSeasonWeeks + i + 1
+	}
+
+	bracket, err := s.api.GetPlayoffBracket(weeks)
+	if err != nil {
+		return models.PlayoffBracketReport{}, fmt.Errorf("error fetching playoff bracket: %w", err)
+	}
+	return bracket, nil
+}
+
+// GetNearbyProGames returns the NFL games of the previous, current and next
+// scoring periods, each ordered by kickoff.
+func (s *FantasyService) GetNearbyProGames() ([][]models.ProGame, error) {
+	metadata, err := s.getLeagueMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching league metadata: %w", err)
+	}
+
+	var weeks [][]models.ProGame
+	for period := max(metadata.CurrentScoringPeriod-1, 1); period <= metadata.CurrentScoringPeriod+1 && period <= metadata.LastWeek; period++ {
+		games, err := s.api.GetProGames(period)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching pro games: %w", err)
+		}
+		weeks = append(weeks, games)
+	}
+	return weeks, nil
+}
+
+// GetLiveScoreboard returns the current week's scores along with how many
+// starters are still to play or playing.
+func (s *FantasyService) GetLiveScoreboard() (models.LiveScoreboard, error) {
+	metadata, err := s.getLeagueMetadata()
+	if err != nil {
+		return models.LiveScoreboard{}, fmt.Errorf("error fetching league metadata: %w", err)
+	}
+
+	scoreboard, err := s.api.GetLiveScoreboard(metadata.CurrentWeek, metadata.CurrentScoringPeriod, time.Now())
+	if err != nil {
+		return models.LiveScoreboard{}, fmt.Errorf("error fetching live scoreboard: %w", err)
+	}
+
+	return scoreboard, nil
+}
+
+// GetInjuryChanges compares every rostered player's injury status with the
+// previous call and returns the players whose status changed. The first call
+// only records a baseline. Players who were just added to a roster are not
+// reported either, since there is nothing to compare against.
+func (s *FantasyService) GetInjuryChanges() ([]models.InjuryChange, error) {
+	metadata, err := s.getLeagueMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching league metadata: %w", err)
+	}
+
+	statuses, err := s.api.GetRosterStatuses(metadata.CurrentScoringPeriod, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error fetching roster statuses: %w", err)
+	}
+
+	previous, seen := s.repo.GetInjuryStatuses()
+
+	current := make(map[int]string, len(statuses))
+	var changes []models.InjuryChange
+	for _, player := range statuses {
+		current[player.PlayerID] = player.InjuryStatus
+
+		old, ok := previous[player.PlayerID]
+		if !seen || !ok || old == player.InjuryStatus {
+			continue
+		}
+
+		changes = append(changes, models.InjuryChange{
+			Player:    player,
+			OldStatus: old,
+			NewStatus: player.InjuryStatus,
+			Urgent:    player.IsStarter && !player.GameStarted,
+		})
+	}
+
+	s.repo.SaveInjuryStatuses(current)
+	return changes, nil
+}
+
+// GetNewTransactions returns the roster moves of the current and previous
+// scoring periods that were not returned by the previous call. The first call
+// only records a baseline so a restart doesn't replay the week.
+func (s *FantasyService) GetNewTransactions() ([]models.Transaction, error) {
+	metadata, err := s.getLeagueMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching league metadata: %w", err)
+	}
+
+	// Waivers processed overnight can land in the period that just ended.
+	var transactions []models.Transaction
+	for period := max(metadata.CurrentScoringPeriod-1, 1); period <= metadata.CurrentScoringPeriod; period++ {
+		periodTransactions, err := s.api.GetTransactions(period)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching transactions: %w", err)
+		}
+		transactions = append(transactions, periodTransactions...)
+	}
+
+	previous, seen := s.repo.GetSeenTransactions()
+
+	current := make(map[string]bool, len(transactions))
+	var fresh []models.Transaction
+	for _, t := range transactions {
+		if current[t.ID] {
+			continue
+		}
+		current[t.ID] = true
+		if seen && !previous[t.ID] {
+			fresh = append(fresh, t)
+		}
+	}
+
+	s.repo.SaveSeenTransactions(current)
+	return fresh, nil
+}
+
+// transactionHistoryLimit is how many activity entries /transactions looks at.
+const transactionHistoryLimit = 50
+
+// GetTransactionHistory returns recent roster moves, optionally only those
+// involving the team matching teamName.
+func (s *FantasyService) GetTransactionHistory(teamName string) (models.TransactionsReport, error) {
+	if teamName == "" {
+		return s.GetTransactionHistoryByTeamID(0)
+	}
+
+	matches := s.api.MatchTeamIDs(teamName)
+	switch len(matches) {
+	case 0:
+		return models.TransactionsReport{}, fmt.Errorf("no team found matching '%s'", teamName)
+	case 1:
+		return s.GetTransactionHistoryByTeamID(matches[0])
+	}
+
+	ambiguous := &AmbiguousMatchError{Kind: "team", Query: teamName}
+	for _, id := range matches {
+		ambiguous.Choices = append(ambiguous.Choices, Choice{ID: id, Label: getTeamName(id)})
+	}
+	return models.TransactionsReport{}, ambiguous
+}
+
+// GetTransactionHistoryByTeamID returns recent roster moves involving the
+// team, or all of them when teamID is 0.
+func (s *FantasyService) GetTransactionHistoryByTeamID(teamID int) (models.TransactionsReport, error) {
 	week, err := s.GetCurrentWeek()
 	if err != nil {
-		return "", fmt.Errorf("error fetching current week: %w", err)
+		return models.TransactionsReport{}, fmt.Errorf("error fetching current week: %w", err)
 	}
 
-	currentScores, err := s.api.GetCurrentScores(week)
+	transactions, err := s.api.GetRecentActivity(transactionHistoryLimit, week)
 	if err != nil {
-		return "", fmt.Errorf("error fetching current scores: %w", err)
+		return models.TransactionsReport{}, fmt.Errorf("error fetching transactions: %w", err)
+	}
+
+	if teamID == 0 {
+		return models.TransactionsReport{Transactions: transactions}, nil
 	}
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("🏈 *Week %d Matchups*\n\n", week))
+	report := models.TransactionsReport{TeamID: teamID, Team: getTeamName(teamID)}
+	for _, t := range transactions {
+		if involvesTeam(t, teamID, report.Team) {
+			report.Transactions = append(report.Transactions, t)
+		}
+	}
+	return report, nil
+}
 
-	slog.Info("Matchups", "matchups", len(currentScores))
-	for _, score := range currentScores {
-		homeTeam := getTeamName(score.HomeTeamID)
-		awayTeam := getTeamName(score.AwayTeamID)
+func involvesTeam(t models.Transaction, teamID int, teamName string) bool {
+	if t.TeamID == teamID {
+		return true
+	}
+	for _, item := range t.Items {
+		if item.FromTeam == teamName || item.ToTeam == teamName {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPlayerGameLog reports the season of the player matching playerName.
+func (s *FantasyService) GetPlayerGameLog(playerName string) (models.PlayerGameLog, error) {
+	result, err := s.WhoHas(playerName)
+	if err != nil {
+		return models.PlayerGameLog{}, err
+	}
+	if !result.Found {
+		return models.PlayerGameLog{}, fmt.Errorf("no player found matching '%s'", playerName)
+	}
 
-		sb.WriteString(fmt.Sprintf("*%s* vs *%s*\n", homeTeam, awayTeam))
-		sb.WriteString(fmt.Sprintf("Projected: %.2f - %.2f\n", score.HomeProjected, score.AwayProjected))
+	return s.GetPlayerGameLogByID(result.PlayerID)
+}
 
-		if score.HomeScore > 0 || score.AwayScore > 0 {
-			sb.WriteString(fmt.Sprintf("Current: %.2f - %.2f", score.HomeScore, score.AwayScore))
-			if score.IsCompleted {
-				sb.WriteString(" (Final)")
+// GetPlayerGameLogByID reports the season of a player picked from an
+// ambiguous search.
+func (s *FantasyService) GetPlayerGameLogByID(playerID int) (models.PlayerGameLog, error) {
+	week, err := s.GetCurrentWeek()
+	if err != nil {
+		return models.PlayerGameLog{}, fmt.Errorf("error fetching current week: %w", err)
+	}
+
+	gameLog, err := s.api.GetPlayerGameLog(playerID, week, s.scoringSettings())
+	if err != nil {
+		return models.PlayerGameLog{}, fmt.Errorf("error fetching player game log: %w", err)
+	}
+
+	return gameLog, nil
+}
+
+// FreeAgentPositions are the positions /fa accepts.
+var FreeAgentPositions = []string{"QB", "RB", "WR", "TE", "FLEX", "D/ST", "K"}
+
+// freeAgentReportSize is how many players the free agent report lists.
+const freeAgentReportSize = 25
+
+// GetFreeAgents reports the best available players, optionally only at
+// position, which is matched case-insensitively against FreeAgentPositions.
+func (s *FantasyService) GetFreeAgents(position string) (models.FreeAgentReport, error) {
+	if position != "" {
+		position = strings.ToUpper(position)
+		if position == "DST" || position == "DEF" {
+			position = "D/ST"
+		}
+		if !slices.Contains(FreeAgentPositions, position) {
+			return models.FreeAgentReport{}, fmt.Errorf("unknown position %s, use one of %s", position, strings.Join(FreeAgentPositions, ", "))
+		}
+	}
+
+	week, err := s.GetCurrentWeek()
+	if err != nil {
+		return models.FreeAgentReport{}, fmt.Errorf("error fetching current week: %w", err)
+	}
+
+	report, err := s.api.GetFreeAgentReport(position, week, freeAgentReportSize)
+	if err != nil {
+		return models.FreeAgentReport{}, fmt.Errorf("error fetching free agents: %w", err)
+	}
+
+	return report, nil
+}
+
+// WatchPlayer adds the player matching playerName to a user's watchlist.
+func (s *FantasyService) WatchPlayer(userID int64, playerName string) (models.WatchedPlayer, error) {
+	result, err := s.WhoHas(playerName)
+	if err != nil {
+		return models.WatchedPlayer{}, err
+	}
+	if !result.Found {
+		return models.WatchedPlayer{}, fmt.Errorf("no player found matching '%s'", playerName)
+	}
+
+	return s.WatchPlayerByID(userID, result.PlayerID)
+}
+
+// WatchPlayerByID adds a player to a user's watchlist and records their
+// current state so the next check only reports later changes.
+func (s *FantasyService) WatchPlayerByID(userID int64, playerID int) (models.WatchedPlayer, error) {
+	week, err := s.GetCurrentWeek()
+	if err != nil {
+		return models.WatchedPlayer{}, fmt.Errorf("error fetching current week: %w", err)
+	}
+
+	states, err := s.api.GetPlayerStates([]int{playerID}, week)
+	if err != nil {
+		return models.WatchedPlayer{}, fmt.Errorf("error fetching player: %w", err)
+	}
+	if len(states) == 0 {
+		return models.WatchedPlayer{}, fmt.Errorf("player #%d not found", playerID)
+	}
+
+	player := models.WatchedPlayer{PlayerID: playerID, Name: states[0].Name}
+	s.repo.AddWatch(userID, player)
+	if _, ok := s.repo.GetWatchedState(playerID); !ok {
+		s.repo.SaveWatchedState(states[0])
+	}
+
+	return player, nil
+}
+
+// UnwatchPlayer removes the player matching playerName from a user's
+// watchlist. Only the watchlist is searched.
+func (s *FantasyService) UnwatchPlayer(userID int64, playerName string) (models.WatchedPlayer, error) {
+	query := strings.ToLower(strings.TrimSpace(playerName))
+
+	var matches []models.WatchedPlayer
+	for _, player := range s.repo.GetWatchlist(userID) {
+		name := strings.ToLower(player.Name)
+		if name == query {
+			matches = []models.WatchedPlayer{player}
+			break
+		}
+		if strings.Contains(name, query) {
+			matches = append(matches, player)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return models.WatchedPlayer{}, fmt.Errorf("'%s' is not on your watchlist", playerName)
+	case 1:
+		s.repo.RemoveWatch(userID, matches[0].PlayerID)
+		return matches[0], nil
+	}
+
+	ambiguous := &AmbiguousMatchError{Kind: "player", Query: playerName}
+	for _, player := range matches {
+		ambiguous.Choices = append(ambiguous.Choices, Choice{ID: player.PlayerID, Label: player.Name})
+	}
+	return models.WatchedPlayer{}, ambiguous
+}
+
+// UnwatchPlayerByID removes a player from a user's watchlist.
+func (s *FantasyService) UnwatchPlayerByID(userID int64, playerID int) models.WatchedPlayer {
+	for _, player := range s.repo.GetWatchlist(userID) {
+		if player.PlayerID == playerID {
+			s.repo.RemoveWatch(userID, playerID)
+			return player
+		}
+	}
+	return models.WatchedPlayer{PlayerID: playerID}
+}
+
+func (s *FantasyService) GetWatchlist(userID int64) models.WatchlistReport {
+	return models.WatchlistReport{Players: s.repo.GetWatchlist(userID)}
+}
+
+// CheckWatchedPlayers compares every watched player with their last known
+// state and returns the changes for each watching user: drops to free agency
+// or waivers, injury status changes and returns from IR.
+func (s *FantasyService) CheckWatchedPlayers() (map[int64][]models.WatchEvent, error) {
+	watchers := s.repo.GetWatchers()
+	if len(watchers) == 0 {
+		return nil, nil
+	}
+
+	week, err := s.GetCurrentWeek()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching current week: %w", err)
+	}
+
+	playerIDs := make([]int, 0, len(watchers))
+	for playerID := range watchers {
+		playerIDs = append(playerIDs, playerID)
+	}
+
+	states, err := s.api.GetPlayerStates(playerIDs, week)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching watched players: %w", err)
+	}
+
+	events := make(map[int64][]models.WatchEvent)
+	for _, state := range states {
+		previous, ok := s.repo.GetWatchedState(state.PlayerID)
+		s.repo.SaveWatchedState(state)
+		if !ok {
+			continue
+		}
+
+		for _, event := range watchEvents(previous, state) {
+			for _, userID := range watchers[state.PlayerID] {
+				events[userID] = append(events[userID], event)
 			}
-			sb.WriteString("\n")
 		}
+	}
 
-		sb.WriteString("\n")
+	return events, nil
+}
+
+func watchEvents(previous, current models.PlayerState) []models.WatchEvent {
+	var events []models.WatchEvent
+
+	if previous.Availability == models.Rostered && current.Availability != models.Rostered {
+		events = append(events, models.WatchEvent{
+			Kind:     models.WatchDropped,
+			Player:   current,
+			FromTeam: previous.TeamName,
+		})
+	}
+
+	if previous.InjuryStatus != current.InjuryStatus {
+		kind := models.WatchInjury
+		if previous.InjuryStatus == "INJURY_RESERVE" {
+			kind = models.WatchOffIR
+		}
+		events = append(events, models.WatchEvent{
+			Kind:      kind,
+			Player:    current,
+			OldStatus: previous.InjuryStatus,
+			NewStatus: current.InjuryStatus,
+		})
 	}
 
-	return sb.String(), nil
+	return events
+}
+
+// SetLiveAlerts subscribes or unsubscribes a chat from live game alerts.
+func (s *FantasyService) SetLiveAlerts(chatID int64, enabled bool) {
+	if enabled {
+		s.repo.AddAlertChat(chatID)
+	} else {
+		s.repo.RemoveAlertChat(chatID)
+	}
 }
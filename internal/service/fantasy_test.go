@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omarshaarawi/coachbot/internal/models"
+)
+
+// TestSeasonPhase walks a league through the weeks where its phase changes:
+// a 14-week regular season, a one-week first round and a championship
+// matchup that spans scoring periods 16 and 17. The metadata cache has to
+// expire quickly around each change, or the old phase sticks for a day.
+func TestSeasonPhase(t *testing.T) {
+	tests := []struct {
+		name      string
+		drafted   bool
+		week      int
+		period    int
+		finalDone bool
+		want      models.SeasonPhase
+		maxAge    time.Duration
+	}{
+		{name: "before the draft", week: 1, period: 1, want: models.PhasePreseason, maxAge: metadataBoundaryTTL},
+		{name: "draft done", drafted: true, week: 1, period: 1, want: models.PhaseRegularSeason, maxAge: metadataTTL},
+		{name: "week before the last regular week", drafted: true, week: 13, period: 13, want: models.PhaseRegularSeason, maxAge: metadataTTL},
+		{name: "last regular week", drafted: true, week: 14, period: 14, want: models.PhaseRegularSeason, maxAge: metadataBoundaryTTL},
+		{name: "first playoff week", drafted: true, week: 15, period: 15, want: models.PhasePlayoffs, maxAge: metadataBoundaryTTL},
+		{name: "championship, first period", drafted: true, week: 16, period: 16, want: models.PhasePlayoffs, maxAge: metadataBoundaryTTL},
+		{name: "championship, second period", drafted: true, week: 16, period: 17, want: models.PhasePlayoffs, maxAge: metadataBoundaryTTL},
+		{name: "after the final, results pending", drafted: true, week: 16, period: 18, want: models.PhasePlayoffs, maxAge: metadataBoundaryTTL},
+		{name: "after the final, results posted", drafted: true, week: 16, period: 18, finalDone: true, want: models.PhaseOffseason, maxAge: metadataBoundaryTTL},
+		{name: "two periods after the final", drafted: true, week: 16, period: 19, want: models.PhaseOffseason, maxAge: metadataBoundaryTTL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := &models.LeagueMetadata{
+				IsActive:             true,
+				DraftComplete:        tt.drafted,
+				CurrentWeek:          tt.week,
+				CurrentScoringPeriod: tt.period,
+				FirstWeek:            1,
+				LastWeek:             17,
+				RegularSeasonWeeks:   14,
+				PlayoffTeams:         4,
+			}
+			if got := seasonPhase(metadata, tt.finalDone); got != tt.want {
+				t.Errorf("seasonPhase = %s, want %s", got, tt.want)
+			}
+			if got := metadataMaxAge(metadata); got != tt.maxAge {
+				t.Errorf("metadataMaxAge = %v, want %v", got, tt.maxAge)
+			}
+		})
+	}
+}
+
+func TestSeasonPhaseWithoutPlayoffs(t *testing.T) {
+	// Leagues without playoffs report no regular season length, so every
+	// matchup week is regular season until the last scoring period is over.
+	metadata := &models.LeagueMetadata{IsActive: true, DraftComplete: true, CurrentWeek: 17, CurrentScoringPeriod: 17, LastWeek: 17}
+	if got := seasonPhase(metadata, false); got != models.PhaseRegularSeason {
+		t.Errorf("last week: seasonPhase = %s, want %s", got, models.PhaseRegularSeason)
+	}
+
+	metadata.CurrentScoringPeriod = 18
+	if got := seasonPhase(metadata, true); got != models.PhaseOffseason {
+		t.Errorf("after the last week: seasonPhase = %s, want %s", got, models.PhaseOffseason)
+	}
+
+	metadata.IsActive = false
+	if got := seasonPhase(metadata, false); got != models.PhaseOffseason {
+		t.Errorf("inactive: seasonPhase = %s, want %s", got, models.PhaseOffseason)
+	}
+}